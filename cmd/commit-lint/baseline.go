@@ -0,0 +1,74 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"commit-linter/internal/baseline"
+	"commit-linter/internal/git"
+)
+
+func handleBaseline(args []string) {
+	if len(args) == 0 || args[0] != "create" {
+		fmt.Println("Usage: commit-lint baseline create [--range from..to | --force] [--output file]")
+		os.Exit(1)
+	}
+
+	fs := flag.NewFlagSet("baseline create", flag.ExitOnError)
+	rangeStr := fs.String("range", "", "Update the commits in this range, keeping the rest of an existing baseline")
+	force := fs.Bool("force", false, "Replace an existing baseline with one for all history")
+	output := fs.String("output", "", "Baseline file (default: "+baseline.FileName+" in the repository root)")
+	fs.BoolVar(&noCache, "no-cache", false, "Don't read or write the result cache")
+	fs.Parse(args[1:])
+
+	repo, err := git.NewRepository("")
+	if err != nil {
+		fmt.Printf("❌ Not a Git repository: %v\n", err)
+		os.Exit(1)
+	}
+
+	path := *output
+	if path == "" {
+		path = baseline.Path(repo.Path)
+	}
+
+	// A range updates the existing baseline; replacing it would drop the
+	// commits outside the range, which then fail history checks
+	b := baseline.New()
+	if *rangeStr != "" {
+		if b, err = baseline.Load(path); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+	} else if _, err := os.Stat(path); err == nil && !*force {
+		fmt.Printf("❌ %s already exists\n", path)
+		fmt.Println("   Use --force to replace it, or --range from..to to update part of it")
+		os.Exit(1)
+	}
+
+	commits := historyCommits(repo, *rangeStr)
+
+	checker := newLintChecker(repo)
+	affected := 0
+
+	for _, commit := range commits {
+		result, _ := checker.lint(commit)
+		if result == nil {
+			continue
+		}
+		b.Set(commit.Hash, result)
+		if len(result.Violations) > 0 {
+			affected++
+		}
+	}
+	checker.save()
+
+	if err := b.Save(path); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("✅ Baselined %d of %d commit(s); %d violation(s) recorded in total\n", affected, len(commits), b.Len())
+	fmt.Printf("   Written to %s — commit it so history checks only fail on new violations\n", path)
+}
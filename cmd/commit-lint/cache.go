@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"commit-linter/internal/cache"
+	"commit-linter/internal/git"
+)
+
+func handleCache(args []string) {
+	if len(args) != 1 || args[0] != "clear" {
+		fmt.Println("Usage: commit-lint cache clear")
+		os.Exit(1)
+	}
+
+	repo, err := git.NewRepository("")
+	if err != nil {
+		fmt.Printf("❌ Not a Git repository: %v\n", err)
+		os.Exit(1)
+	}
+
+	path := cache.Path(repo.CommonDir)
+	removed, err := cache.Clear(path)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	if removed {
+		fmt.Printf("✅ Removed result cache %s\n", path)
+	} else {
+		fmt.Println("ℹ️  No result cache to remove")
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"commit-linter/internal/changelog"
+	"commit-linter/internal/config"
+	"commit-linter/internal/git"
+)
+
+func handleChangelog(args []string) {
+	fs := flag.NewFlagSet("changelog", flag.ExitOnError)
+	from := fs.String("from", "", "Start reference, exclusive (e.g., v1.2.0)")
+	to := fs.String("to", "HEAD", "End reference, inclusive")
+	version := fs.String("version", "", "Release heading (defaults to \"Unreleased\")")
+	output := fs.String("output", "", "Prepend to this file instead of printing to stdout")
+	noLinks := fs.Bool("no-links", false, "Do not link commit hashes and issues")
+	fs.Parse(args)
+
+	if *from == "" {
+		fmt.Println("❌ --from is required")
+		fmt.Println("   Example: commit-lint changelog --from v1.2.0 --to HEAD")
+		os.Exit(1)
+	}
+
+	repo, err := git.NewRepository("")
+	if err != nil {
+		fmt.Printf("❌ Not a Git repository: %v\n", err)
+		os.Exit(1)
+	}
+
+	cfg, err := config.Load(repo.Path)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	preset := releasePreset(cfg, "changelog")
+
+	commits, err := repo.GetCommitsInRange(*from, *to)
+	if err != nil {
+		fmt.Printf("❌ Failed to get commits: %v\n", err)
+		os.Exit(1)
+	}
+
+	opts := changelog.Options{
+		Version: *version,
+		Date:    time.Now().Format("2006-01-02"),
+		Config:  cfg.Changelog,
+		Preset:  preset,
+	}
+	if !*noLinks {
+		opts.RepoURL = repo.GetRemoteURL("origin")
+	}
+
+	section, skipped := changelog.Generate(commits, opts)
+
+	// Diagnostics go to stderr so stdout stays valid Markdown
+	if len(skipped) > 0 {
+		fmt.Fprintf(os.Stderr, "⚠️  Skipped %d commit(s) not following %s\n", len(skipped), preset.Title)
+	}
+
+	if *output == "" {
+		fmt.Print(section)
+		return
+	}
+
+	existing, err := os.ReadFile(*output)
+	if err != nil && !os.IsNotExist(err) {
+		fmt.Printf("❌ Failed to read %s: %v\n", *output, err)
+		os.Exit(1)
+	}
+
+	content := changelog.Prepend(string(existing), section)
+	if err := os.WriteFile(*output, []byte(content), 0644); err != nil {
+		fmt.Printf("❌ Failed to write %s: %v\n", *output, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("✅ Updated %s (%d commits)\n", *output, len(commits)-len(skipped))
+}
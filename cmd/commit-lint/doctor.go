@@ -0,0 +1,192 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"commit-linter/internal/config"
+	"commit-linter/internal/git"
+)
+
+// doctor collects diagnostic results and remembers whether any failed
+type doctor struct {
+	problems int
+}
+
+func (d *doctor) ok(format string, args ...interface{}) {
+	fmt.Printf("  ✅ "+format+"\n", args...)
+}
+
+func (d *doctor) info(format string, args ...interface{}) {
+	fmt.Printf("  ℹ️  "+format+"\n", args...)
+}
+
+func (d *doctor) warn(fix, format string, args ...interface{}) {
+	d.problems++
+	fmt.Printf("  ⚠️  "+format+"\n", args...)
+	if fix != "" {
+		fmt.Printf("     → %s\n", fix)
+	}
+}
+
+func (d *doctor) fail(fix, format string, args ...interface{}) {
+	d.problems++
+	fmt.Printf("  ❌ "+format+"\n", args...)
+	if fix != "" {
+		fmt.Printf("     → %s\n", fix)
+	}
+}
+
+func handleDoctor(args []string) {
+	fs := flag.NewFlagSet("doctor", flag.ExitOnError)
+	fs.Parse(args)
+
+	d := &doctor{}
+
+	fmt.Println("🩺 commit-lint doctor")
+	fmt.Println(strings.Repeat("─", 50))
+
+	fmt.Println("Environment:")
+	d.ok("commit-lint %s", version)
+	gitVersion, err := git.Version()
+	if err != nil {
+		d.fail("Install Git and make sure it is on PATH", "%v", err)
+		reportDoctor(d)
+		return
+	}
+	d.ok("git %s", gitVersion)
+	fmt.Println()
+
+	repo, err := git.NewRepository("")
+	if err != nil {
+		d.fail("Run commit-lint doctor inside a Git repository", "%v", err)
+		reportDoctor(d)
+		return
+	}
+
+	fmt.Println("Configuration:")
+	if _, err := os.Stat(filepath.Join(repo.Path, config.FileName)); os.IsNotExist(err) {
+		d.info("No %s found, using defaults", config.FileName)
+	} else if _, err := config.Load(repo.Path); err != nil {
+		d.fail("Fix "+config.FileName, "%v", err)
+	} else {
+		d.ok("%s parses", config.FileName)
+	}
+	fmt.Println()
+
+	fmt.Println("Hooks:")
+	checkHooksDir(d, repo)
+	fmt.Println()
+
+	fmt.Println("Repository layout:")
+	checkLayout(d, repo)
+	fmt.Println()
+
+	reportDoctor(d)
+}
+
+// checkHooksDir reports the effective hooks path and the state of each hook
+func checkHooksDir(d *doctor, repo *git.Repository) {
+	hooksDir, err := repo.GetHooksDir()
+	if err != nil {
+		d.fail("", "Cannot determine hooks directory: %v", err)
+		return
+	}
+
+	if hooksPath := repo.GetConfig("core.hooksPath"); hooksPath != "" {
+		d.info("Hooks path: %s (core.hooksPath = %s)", hooksDir, hooksPath)
+	} else {
+		d.info("Hooks path: %s", hooksDir)
+	}
+
+	manager := repo.DetectHookManager()
+	if manager != git.ManagerGit {
+		d.info("Hooks are managed by %s", manager)
+	}
+
+	for _, hook := range git.SupportedHooks {
+		path := filepath.Join(hooksDir, hook)
+		installed, _ := repo.IsHookInstalled(hook)
+
+		if !installed {
+			if hook == git.HookCommitMsg && manager == git.ManagerGit {
+				d.warn("commit-lint --install", "%s hook is not installed", hook)
+			} else if hook == git.HookCommitMsg {
+				d.info("%s hook not in hooks dir; check that %s runs commit-lint", hook, manager)
+			} else {
+				d.info("%s hook is not installed (optional)", hook)
+			}
+			continue
+		}
+
+		info, err := os.Stat(path)
+		if err == nil && info.Mode()&0111 == 0 {
+			d.fail("chmod +x "+path, "%s hook is not executable, Git will skip it", hook)
+			continue
+		}
+
+		hookVersion, _ := repo.HookVersion(hook)
+		switch hookVersion {
+		case version:
+			d.ok("%s hook installed (version %s)", hook, hookVersion)
+		case "":
+			d.warn("commit-lint --install --force --hook "+hook,
+				"%s hook was generated by an older commit-lint without a version stamp", hook)
+		default:
+			d.warn("commit-lint --install --force --hook "+hook,
+				"%s hook was generated by %s, running %s", hook, hookVersion, version)
+		}
+
+		if manager != git.ManagerGit && hook == git.HookCommitMsg {
+			d.warn(fmt.Sprintf("commit-lint --uninstall, then commit-lint --install --target %s", manager),
+				"%s is installed directly although %s manages hooks and may overwrite it", hook, manager)
+		}
+	}
+}
+
+// checkLayout reports whether worktrees and submodules share the hook
+func checkLayout(d *doctor, repo *git.Repository) {
+	relativeHooksPath := false
+	if hooksPath := repo.GetConfig("core.hooksPath"); hooksPath != "" && !filepath.IsAbs(hooksPath) {
+		relativeHooksPath = true
+	}
+
+	d.info("Working tree: %s", repo.TopLevel)
+	if repo.GitDir != repo.CommonDir {
+		d.info("Linked worktree: Git dir %s, hooks shared via %s", repo.GitDir, repo.CommonDir)
+	} else {
+		d.info("Git dir: %s", repo.GitDir)
+	}
+
+	worktrees, err := repo.ListWorktrees()
+	if err == nil && len(worktrees) > 1 {
+		if relativeHooksPath {
+			d.info("%d worktrees; core.hooksPath is relative, so each uses its own checkout of it",
+				len(worktrees))
+		} else {
+			d.ok("%d worktrees share the same hooks", len(worktrees))
+		}
+	} else {
+		d.ok("Single worktree")
+	}
+
+	submodules, err := repo.ListSubmodules()
+	if err == nil && len(submodules) > 0 {
+		d.warn("Run commit-lint --install inside each submodule you commit to",
+			"%d submodule(s) have their own hooks and are not covered: %s",
+			len(submodules), strings.Join(submodules, ", "))
+	}
+}
+
+func reportDoctor(d *doctor) {
+	fmt.Println(strings.Repeat("─", 50))
+	if d.problems == 0 {
+		fmt.Println("✅ No problems found")
+		return
+	}
+	fmt.Printf("⚠️  %d problem(s) found\n", d.problems)
+	os.Exit(1)
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"commit-linter/internal/forge"
+	"commit-linter/internal/git"
+	"commit-linter/internal/stats"
+)
+
+// forgeFlags are the --report-to flags: where to post a commit status and
+// pull request comment after validating a range
+var forgeFlags struct {
+	forge string
+	url   string
+	repo  string
+	pr    int
+}
+
+// reportToForge posts the results when --report-to is given. Reporting is
+// a side channel, so failures are printed but don't change the exit code.
+func reportToForge(entries []stats.Entry) {
+	if forgeFlags.forge == "" {
+		return
+	}
+
+	target := forge.DetectTarget(os.Getenv)
+	if forgeFlags.forge != "auto" {
+		if target.Forge != forgeFlags.forge {
+			target = forge.Target{Forge: forgeFlags.forge}
+		}
+	} else if target.Forge == "" {
+		fmt.Println("⚠️  --report-to auto: no GitHub, Gitea or GitLab CI environment detected")
+		return
+	}
+
+	if forgeFlags.url != "" {
+		target.BaseURL = forgeFlags.url
+	}
+	if forgeFlags.repo != "" {
+		target.Repository = forgeFlags.repo
+	}
+	if forgeFlags.pr > 0 {
+		target.PullRequest = forgeFlags.pr
+	}
+	if target.SHA == "" {
+		// git log lists the newest commit first
+		if len(entries) > 0 {
+			target.SHA = entries[0].Commit.Hash
+		} else if commit, err := headCommit(); err == nil {
+			target.SHA = commit.Hash
+		}
+	}
+
+	client, err := forge.New(forge.Options{
+		Forge:      target.Forge,
+		BaseURL:    target.BaseURL,
+		Repository: target.Repository,
+		Token:      forge.Token(target.Forge, os.Getenv),
+	})
+	if err == nil {
+		err = forge.Report(client, target, entries, configuredPreset())
+	}
+	if err != nil {
+		fmt.Printf("⚠️  Failed to report to %s: %v\n", target.Forge, err)
+		return
+	}
+
+	fmt.Printf("📮 Reported to %s (%s", target.Forge, target.Repository)
+	if target.PullRequest > 0 {
+		fmt.Printf(" #%d", target.PullRequest)
+	}
+	fmt.Println(")")
+}
+
+// headCommit returns the checked-out commit, which an empty range is
+// reported on
+func headCommit() (*git.Commit, error) {
+	repo, err := git.NewRepository("")
+	if err != nil {
+		return nil, err
+	}
+	return repo.GetLastCommit()
+}
@@ -0,0 +1,289 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"commit-linter/internal/baseline"
+	"commit-linter/internal/cache"
+	"commit-linter/internal/config"
+	"commit-linter/internal/git"
+	"commit-linter/internal/ignore"
+	"commit-linter/internal/linter"
+	"commit-linter/internal/notes"
+	"commit-linter/internal/stats"
+)
+
+// historyChecker validates commits from history, applying the ignore rules
+// and the baseline of grandfathered violations. Results are reused from the
+// cache and from commit-lint notes unless --no-cache is given, and recorded
+// as notes with --write-notes.
+type historyChecker struct {
+	repo        *git.Repository
+	linter      *linter.Linter
+	matcher     *ignore.Matcher
+	baseline    *baseline.Baseline
+	cache       *cache.Cache
+	fingerprint string
+	suppressed  int
+
+	// notes holds the results recorded before this run; it is read on
+	// first use, as most runs never need it
+	notes        map[string]notes.Note
+	pendingNotes []string
+	newNotes     map[string]notes.Note
+}
+
+func newHistoryChecker(repo *git.Repository) *historyChecker {
+	h := newLintChecker(repo)
+
+	b, err := baseline.Load(baseline.Path(repo.Path))
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	h.baseline = b
+
+	return h
+}
+
+// newLintChecker returns a checker with an empty baseline, for callers that
+// need every violation in history
+func newLintChecker(repo *git.Repository) *historyChecker {
+	h, err := buildLintChecker(repo)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	return h
+}
+
+// buildLintChecker is newLintChecker for callers that handle many
+// repositories and must not exit on one broken configuration
+func buildLintChecker(repo *git.Repository) (*historyChecker, error) {
+	cfg, err := repositoryConfig(repo)
+	if err != nil {
+		return nil, err
+	}
+	matcher, err := ignore.New(cfg.Ignore)
+	if err != nil {
+		return nil, err
+	}
+	lint, err := buildLinter(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &historyChecker{
+		repo:        repo,
+		linter:      lint,
+		matcher:     matcher,
+		baseline:    baseline.New(),
+		fingerprint: cache.Fingerprint(version, ruleSet(lint), cfg.Ignore, cfg.Directives, cfg.Scoring, minScoreOverride),
+		newNotes:    map[string]notes.Note{},
+	}
+
+	if !noCache {
+		h.cache = cache.Load(cache.Path(repo.CommonDir), h.fingerprint)
+	}
+
+	return h, nil
+}
+
+// ruleSet describes the rules a linter enforces. It is part of the cache
+// fingerprint because development builds change rules without changing
+// the version.
+func ruleSet(lint *linter.Linter) []string {
+	preset := lint.Preset()
+	rules := []string{preset.Name, preset.ParseFailedMessage()}
+	for _, rule := range preset.Rules() {
+		rules = append(rules, rule.Name+"|"+rule.Level+"|"+rule.Message)
+	}
+	return rules
+}
+
+// repositoryConfig loads the configuration of a repository. Bare
+// repositories such as mirrors have no working tree, so unless one is
+// placed in the Git directory, the file committed on HEAD is used.
+func repositoryConfig(repo *git.Repository) (*config.Config, error) {
+	if !repo.IsBare {
+		return config.Load(repo.Path)
+	}
+	if _, err := os.Stat(filepath.Join(repo.Path, config.FileName)); err == nil {
+		return config.Load(repo.Path)
+	}
+
+	data, found, err := repo.ReadFile("HEAD", config.FileName)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return config.Default(), nil
+	}
+	return config.Parse(data, "HEAD:"+config.FileName)
+}
+
+// readNotes loads the recorded results. Notes are optional, so a failure
+// to read them is reported but not fatal.
+func readNotes(repo *git.Repository) map[string]notes.Note {
+	recorded := map[string]notes.Note{}
+
+	contents, err := repo.ReadNotes(notes.Ref)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  %v\n", err)
+		return recorded
+	}
+
+	for hash, content := range contents {
+		if n, err := notes.Parse(content); err == nil {
+			recorded[hash] = n
+		}
+	}
+	return recorded
+}
+
+// lint validates a commit without applying the baseline. Ignored commits
+// return a nil result and the reason they were skipped.
+func (h *historyChecker) lint(commit *git.Commit) (*linter.ValidationResult, string) {
+	result, reason, cached := h.reuse(commit.Hash)
+
+	if !cached {
+		var ignored bool
+		if reason, ignored = h.matcher.Match(commit); !ignored {
+			result = h.linter.Validate(commit.FullMessage())
+		}
+
+		if h.cache != nil {
+			e := cache.Entry{Ignored: reason}
+			if result != nil {
+				snapshot := result.Snapshot()
+				e.Result = &snapshot
+			}
+			h.cache.Put(commit.Hash, e)
+		}
+	}
+
+	if writeNotes {
+		if n, ok := h.recorded(commit.Hash); !ok || !n.Current(version, h.fingerprint) {
+			if _, queued := h.newNotes[commit.Hash]; !queued {
+				h.pendingNotes = append(h.pendingNotes, commit.Hash)
+			}
+			h.newNotes[commit.Hash] = notes.New(version, h.fingerprint, result, reason)
+		}
+	}
+
+	return result, reason
+}
+
+// reuse looks up a result computed by an earlier run with the same rules,
+// first in the cache and then in the notes
+func (h *historyChecker) reuse(hash string) (*linter.ValidationResult, string, bool) {
+	if h.cache == nil {
+		return nil, "", false
+	}
+
+	if e, ok := h.cache.Get(hash); ok {
+		if e.Result == nil {
+			return nil, e.Ignored, true
+		}
+		if result, ok := h.linter.Restore(*e.Result); ok {
+			return result, "", true
+		}
+	}
+
+	if n, ok := h.recorded(hash); ok && n.Current(version, h.fingerprint) {
+		if n.Result == nil {
+			h.cache.Put(hash, cache.Entry{Ignored: n.Ignored})
+			return nil, n.Ignored, true
+		}
+		if result, ok := h.linter.Restore(*n.Result); ok {
+			h.cache.Put(hash, cache.Entry{Result: n.Result})
+			return result, "", true
+		}
+	}
+
+	return nil, "", false
+}
+
+// recorded returns the note recorded for a commit before this run
+func (h *historyChecker) recorded(hash string) (notes.Note, bool) {
+	if h.notes == nil {
+		h.notes = readNotes(h.repo)
+	}
+	n, ok := h.notes[hash]
+	return n, ok
+}
+
+// check validates a commit and applies the baseline
+func (h *historyChecker) check(commit *git.Commit) (*linter.ValidationResult, string) {
+	result, reason := h.lint(commit)
+	if result != nil {
+		h.suppressed += len(h.baseline.Apply(commit.Hash, result))
+	}
+	return result, reason
+}
+
+// checkAll checks every commit, in the form the report builders take
+func (h *historyChecker) checkAll(commits []*git.Commit) []stats.Entry {
+	entries := make([]stats.Entry, 0, len(commits))
+	for _, commit := range commits {
+		result, reason := h.check(commit)
+		entries = append(entries, h.entry(commit, result, reason))
+	}
+	return entries
+}
+
+// entry records the outcome of checking a commit, parsed with the
+// linter's preset for statistics
+func (h *historyChecker) entry(commit *git.Commit, result *linter.ValidationResult, reason string) stats.Entry {
+	e := stats.Entry{Commit: commit, Result: result, Ignored: reason}
+	if result != nil {
+		e.Parsed = h.linter.Parse(commit.FullMessage())
+	}
+	return e
+}
+
+// printBaselineSummary reports suppressed and stale baseline entries
+func (h *historyChecker) printBaselineSummary() {
+	if h.suppressed > 0 {
+		fmt.Printf("   Baselined: %d known violation(s) suppressed\n", h.suppressed)
+	}
+
+	stale := h.baseline.Stale()
+	if len(stale) == 0 {
+		return
+	}
+
+	fmt.Printf("   Stale baseline entries: %d (no longer violated)\n", len(stale))
+	for _, e := range stale {
+		fmt.Printf("     • %.7s %s\n", e.Hash, e.Rule)
+	}
+	fmt.Println("     → Regenerate with: commit-lint baseline create --force")
+}
+
+// save persists newly computed results to the cache and, with
+// --write-notes, to notes. A cache that cannot be written only costs speed,
+// so failures there are reported but not fatal.
+func (h *historyChecker) save() {
+	if h.cache != nil {
+		if err := h.cache.Save(); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  %v\n", err)
+		}
+	}
+
+	if len(h.pendingNotes) == 0 {
+		return
+	}
+	pending := make(map[string]string, len(h.pendingNotes))
+	for _, hash := range h.pendingNotes {
+		pending[hash] = h.newNotes[hash].Encode()
+	}
+	if err := h.repo.AddNotes(notes.Ref, pending, "Notes added by 'commit-lint --write-notes'"); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("📝 Recorded %d result(s) in %s\n", len(h.pendingNotes), notes.Ref)
+	fmt.Printf("   Share them with: git push origin %s\n", notes.Ref)
+	h.pendingNotes = nil
+}
@@ -1,17 +1,45 @@
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
+	"commit-linter/internal/ci"
+	"commit-linter/internal/config"
 	"commit-linter/internal/formatter"
 	"commit-linter/internal/git"
+	"commit-linter/internal/ignore"
 	"commit-linter/internal/linter"
+	"commit-linter/internal/notes"
+	"commit-linter/internal/stats"
 )
 
+// version is stamped into generated hooks; release builds override it with
+// -ldflags "-X main.version=..."
+var version = "2.0.0"
+
+// minScoreOverride is the --min-score flag; negative means "use config"
+var minScoreOverride = -1
+
+// noCache is the --no-cache flag: lint every commit instead of reusing
+// cached results
+var noCache bool
+
+// writeNotes is the --write-notes flag: record each result as a note under
+// refs/notes/commit-lint
+var writeNotes bool
+
 func main() {
+	// Subcommands have their own flags and are dispatched before the
+	// top-level flags are parsed
+	if len(os.Args) > 1 && runSubcommand(os.Args[1], os.Args[2:]) {
+		return
+	}
+
 	var (
 		filePath      string
 		showHelp      bool
@@ -21,6 +49,12 @@ func main() {
 		lastCommit    bool
 		commitCount   int
 		commitRange   string
+		baseBranch    string
+		ciMode        bool
+		hookName      string
+		installTarget string
+		missingBinary string
+		asJSON        bool
 		force         bool
 	)
 
@@ -32,18 +66,31 @@ func main() {
 	flag.BoolVar(&lastCommit, "last", false, "Validate last commit")
 	flag.IntVar(&commitCount, "count", 1, "Number of commits to validate (with --last)")
 	flag.StringVar(&commitRange, "range", "", "Validate commits in range (e.g., HEAD~3..HEAD)")
-	flag.BoolVar(&force, "force", false, "Force overwrite existing hook")
+	flag.StringVar(&baseBranch, "branch", "", "Validate commits on the current branch not in this base branch")
+	flag.BoolVar(&ciMode, "ci", false, "Validate the pull request or push range detected from CI environment variables")
+	flag.StringVar(&hookName, "hook", git.HookCommitMsg, "Hook to install, uninstall or check (commit-msg, pre-push)")
+	flag.StringVar(&installTarget, "target", string(git.ManagerAuto), "Where to install: auto, git, pre-commit, lefthook, husky")
+	flag.StringVar(&missingBinary, "missing-binary", "", "Hook behavior when commit-lint is not found: fail or warn (default from config)")
+	flag.IntVar(&minScoreOverride, "min-score", -1, "Fail messages scoring below this (default from config)")
+	flag.BoolVar(&asJSON, "json", false, "Print the validation result as JSON")
+	flag.BoolVar(&force, "force", false, "Update an existing commit-lint hook")
+	flag.BoolVar(&noCache, "no-cache", false, "Don't read or write the result cache")
+	flag.BoolVar(&writeNotes, "write-notes", false, "Record results as git notes under "+notes.Ref)
+	flag.StringVar(&forgeFlags.forge, "report-to", "", "Post a commit status and PR comment to github, gitlab, gitea or auto (with --range, --branch, --ci)")
+	flag.StringVar(&forgeFlags.url, "forge-url", "", "Forge API base URL (default: from CI or the public service)")
+	flag.StringVar(&forgeFlags.repo, "forge-repo", "", "Repository as owner/name (default: from CI)")
+	flag.IntVar(&forgeFlags.pr, "pr", 0, "Pull or merge request number to comment on (default: from CI)")
 
 	flag.Parse()
 
 	if showHelp {
-		printHelp()
+		printHelp(configuredPreset())
 		return
 	}
 
 	// Initialize Git repository
 	repo, err := git.NewRepository("")
-	if err != nil && (installHook || uninstallHook || checkHook || lastCommit || commitRange != "") {
+	if err != nil && (installHook || uninstallHook || checkHook || lastCommit || commitRange != "" || baseBranch != "" || ciMode) {
 		fmt.Printf("❌ Not a Git repository: %v\n", err)
 		os.Exit(1)
 	}
@@ -51,13 +98,13 @@ func main() {
 	// Handle Git operations
 	switch {
 	case installHook:
-		handleInstallHook(repo, force)
+		handleInstallHook(repo, hookName, installTarget, missingBinary, force)
 		return
 	case uninstallHook:
-		handleUninstallHook(repo)
+		handleUninstallHook(repo, hookName)
 		return
 	case checkHook:
-		handleCheckHook(repo)
+		handleCheckHook(repo, hookName)
 		return
 	case lastCommit:
 		handleLastCommits(repo, commitCount)
@@ -65,6 +112,12 @@ func main() {
 	case commitRange != "":
 		handleCommitRange(repo, commitRange)
 		return
+	case baseBranch != "":
+		handleBranch(repo, baseBranch)
+		return
+	case ciMode:
+		handleCI(repo)
+		return
 	}
 
 	// Original validation logic
@@ -76,7 +129,7 @@ func main() {
 			fmt.Printf("Error reading file: %v\n", err)
 			os.Exit(1)
 		}
-		message = string(data)
+		message = linter.StripComments(string(data))
 	} else if len(flag.Args()) > 0 {
 		message = flag.Arg(0)
 	} else {
@@ -86,7 +139,7 @@ func main() {
 			if err == nil {
 				data, err := os.ReadFile(commitMsgFile)
 				if err == nil {
-					message = string(data)
+					message = linter.StripComments(string(data))
 				}
 			}
 		}
@@ -102,11 +155,29 @@ func main() {
 		}
 	}
 
+	// Merges, reverts and autosquash commits also pass through the hook
+	configDir := "."
+	if repo != nil {
+		configDir = repo.Path
+	}
+	cfg := loadConfig(configDir)
+	if reason, ok := newIgnoreMatcher(cfg).MatchMessage(message); ok {
+		fmt.Printf("⏭️  Skipping validation (ignored: %s)\n", reason)
+		return
+	}
+
 	// Validate the message
-	result := linter.Validate(message)
+	lint := newLinter(cfg)
+	result := lint.Validate(message)
 
 	// Print results
-	formatter.PrintValidationResult(message, result)
+	if asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(result)
+	} else {
+		formatter.PrintValidationResult(lint.Preset(), lint.Parse(message), result)
+	}
 
 	// Exit with appropriate code
 	if !result.IsValid {
@@ -114,11 +185,75 @@ func main() {
 	}
 }
 
-func handleInstallHook(repo *git.Repository, force bool) {
-	fmt.Println("🔧 Installing Git commit-msg hook...")
+// runSubcommand runs the named subcommand and reports whether it exists
+func runSubcommand(name string, args []string) bool {
+	switch name {
+	case "changelog":
+		handleChangelog(args)
+	case "next-version":
+		handleNextVersion(args)
+	case "pr":
+		handlePullRequest(args)
+	case "baseline":
+		handleBaseline(args)
+	case "stats":
+		handleStats(args)
+	case "report":
+		handleReport(args)
+	case "cache":
+		handleCache(args)
+	case "scan":
+		handleScan(args)
+	case "serve":
+		handleServe(args)
+	case "doctor":
+		handleDoctor(args)
+	case "pre-push":
+		handlePrePush(args)
+	case "pre-receive":
+		handlePreReceive(args)
+	case "update":
+		handleUpdateHook(args)
+	default:
+		return false
+	}
+	return true
+}
+
+func handleInstallHook(repo *git.Repository, hook, target, missingBinary string, force bool) {
+	opts, err := hookOptions(repo, missingBinary)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	manager := git.HookManager(target)
+	if manager == git.ManagerAuto {
+		manager = repo.DetectHookManager()
+		if manager != git.ManagerGit {
+			fmt.Printf("🔍 Detected hook manager: %s\n", manager)
+		}
+	}
+
+	// Hook managers own the hook scripts, so commit-lint is added to
+	// their configuration instead
+	if manager != git.ManagerGit {
+		if hook != git.HookCommitMsg {
+			fmt.Printf("❌ --target %s only supports the commit-msg hook\n", manager)
+			os.Exit(1)
+		}
+		if err := repo.InstallWithManager(manager, force, opts); err != nil {
+			fmt.Printf("❌ Failed to install hook: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("✅ Hook configured successfully!")
+		return
+	}
+
+	fmt.Printf("🔧 Installing Git %s hook...\n", hook)
 	fmt.Println()
 
-	if err := repo.InstallCommitMsgHook(force); err != nil {
+	if err := repo.InstallHook(hook, force, opts); err != nil {
 		fmt.Printf("❌ Failed to install hook: %v\n", err)
 		os.Exit(1)
 	}
@@ -126,6 +261,15 @@ func handleInstallHook(repo *git.Repository, force bool) {
 	fmt.Println()
 	fmt.Println("✅ Hook installed successfully!")
 	fmt.Println()
+
+	if hook == git.HookPrePush {
+		fmt.Println("From now on, commits will be validated before they are pushed.")
+		fmt.Println()
+		fmt.Println("To test the hook:")
+		fmt.Println("  git push  # Blocked if any unpushed commit is invalid")
+		return
+	}
+
 	fmt.Println("From now on, all commit messages will be automatically validated.")
 	fmt.Println()
 	fmt.Println("To test the hook:")
@@ -133,10 +277,37 @@ func handleInstallHook(repo *git.Repository, force bool) {
 	fmt.Println("  git commit -m \"feat: add feature\"  # Should pass")
 }
 
-func handleUninstallHook(repo *git.Repository) {
-	fmt.Println("🔧 Uninstalling Git commit-msg hook...")
+// hookOptions builds the generated hook settings: the absolute path of the
+// running binary, its version, and the missing-binary policy from the flag
+// or the config file
+func hookOptions(repo *git.Repository, missingBinary string) (git.HookOptions, error) {
+	cfg, err := config.Load(repo.Path)
+	if err != nil {
+		return git.HookOptions{}, err
+	}
+	if missingBinary == "" {
+		missingBinary = cfg.Hooks.MissingBinary
+	}
+
+	binary, err := os.Executable()
+	if err != nil {
+		return git.HookOptions{}, fmt.Errorf("failed to locate commit-lint binary: %v", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(binary); err == nil {
+		binary = resolved
+	}
 
-	if err := repo.UninstallCommitMsgHook(); err != nil {
+	return git.HookOptions{
+		Binary:        binary,
+		Version:       version,
+		MissingBinary: missingBinary,
+	}, nil
+}
+
+func handleUninstallHook(repo *git.Repository, hook string) {
+	fmt.Printf("🔧 Uninstalling Git %s hook...\n", hook)
+
+	if err := repo.UninstallHook(hook); err != nil {
 		fmt.Printf("❌ Failed to uninstall hook: %v\n", err)
 		os.Exit(1)
 	}
@@ -144,18 +315,31 @@ func handleUninstallHook(repo *git.Repository) {
 	fmt.Println("✅ Hook uninstalled successfully!")
 }
 
-func handleCheckHook(repo *git.Repository) {
-	installed, err := repo.IsHookInstalled()
+func handleCheckHook(repo *git.Repository, hook string) {
+	installed, err := repo.IsHookInstalled(hook)
 	if err != nil {
 		fmt.Printf("❌ Error checking hook: %v\n", err)
 		os.Exit(1)
 	}
 
 	if installed {
-		fmt.Println("✅ commit-lint hook is installed")
+		fmt.Printf("✅ commit-lint %s hook is installed\n", hook)
+
+		hookVersion, _ := repo.HookVersion(hook)
+		if hookVersion != version {
+			if hookVersion == "" {
+				hookVersion = "an older version"
+			}
+			fmt.Printf("⚠️  Hook was generated by %s (running %s)\n", hookVersion, version)
+			fmt.Printf("   Update with: commit-lint --install --force --hook %s\n", hook)
+		}
 	} else {
-		fmt.Println("❌ commit-lint hook is NOT installed")
-		fmt.Println("   Install with: commit-lint --install")
+		fmt.Printf("❌ commit-lint %s hook is NOT installed\n", hook)
+		if hook == git.HookCommitMsg {
+			fmt.Println("   Install with: commit-lint --install")
+		} else {
+			fmt.Printf("   Install with: commit-lint --install --hook %s\n", hook)
+		}
 	}
 }
 
@@ -168,8 +352,12 @@ func handleLastCommits(repo *git.Repository, count int) {
 		os.Exit(1)
 	}
 
+	checker := newHistoryChecker(repo)
+
 	allValid := true
 	totalScore := 0
+	ignoredCount := 0
+	entries := make([]stats.Entry, 0, len(commits))
 
 	for i, commit := range commits {
 		fmt.Printf("[%d/%d] Commit: %s\n", i+1, len(commits), commit.ShortHash)
@@ -177,16 +365,24 @@ func handleLastCommits(repo *git.Repository, count int) {
 		fmt.Printf("     Author:  %s\n", commit.Author)
 		fmt.Printf("     Date:    %s\n", commit.Date)
 
-		result := linter.Validate(commit.Message)
-
-		if result.IsValid {
-			fmt.Printf("     Status:  ✅ Valid (%d/100)\n", result.Score)
+		result, reason := checker.check(commit)
+		entries = append(entries, checker.entry(commit, result, reason))
+		if result == nil {
+			fmt.Printf("     Status:  ⏭️  Ignored (%s)\n", reason)
+			ignoredCount++
 		} else {
-			fmt.Printf("     Status:  ❌ Invalid (%d/100)\n", result.Score)
-			allValid = false
-		}
+			if result.IsValid {
+				fmt.Printf("     Status:  ✅ Valid (%d/100)\n", result.Score)
+			} else {
+				fmt.Printf("     Status:  ❌ Invalid (%d/100)\n", result.Score)
+				allValid = false
+			}
 
-		totalScore += result.Score
+			totalScore += result.Score
+		}
+		if n, ok := checker.recorded(commit.Hash); ok {
+			fmt.Printf("     Recorded: %s\n", n.Summary())
+		}
 
 		if i < len(commits)-1 {
 			fmt.Println()
@@ -196,12 +392,28 @@ func handleLastCommits(repo *git.Repository, count int) {
 	fmt.Println()
 	fmt.Println(strings.Repeat("─", 50))
 
-	if allValid {
-		avgScore := totalScore / len(commits)
-		fmt.Printf("✅ All %d commits are valid! (Average score: %d/100)\n",
-			len(commits), avgScore)
-	} else {
-		fmt.Printf("❌ Some commits failed validation\n")
+	linted := len(commits) - ignoredCount
+	ignoredNote := ""
+	if ignoredCount > 0 {
+		ignoredNote = fmt.Sprintf(", %d ignored", ignoredCount)
+	}
+
+	switch {
+	case !allValid:
+		fmt.Printf("❌ Some commits failed validation%s\n", ignoredNote)
+	case linted == 0:
+		fmt.Printf("⏭️  All %d commits are ignored\n", len(commits))
+	default:
+		avgScore := totalScore / linted
+		fmt.Printf("✅ All %d commits are valid! (Average score: %d/100%s)\n",
+			linted, avgScore, ignoredNote)
+	}
+	checker.printBaselineSummary()
+	checker.save()
+	// --ci falls back to the last commit, which still replaces the status
+	reportToForge(entries)
+
+	if !allValid {
 		os.Exit(1)
 	}
 }
@@ -225,15 +437,34 @@ func handleCommitRange(repo *git.Repository, rangeStr string) {
 
 	if len(commits) == 0 {
 		fmt.Println("ℹ️  No commits found in the specified range")
+		// Clear a stale result, e.g. after a rebase onto the base
+		reportToForge(nil)
 		return
 	}
 
+	validateCommitList(repo, commits)
+}
+
+// validateCommitList prints one line per commit followed by a summary and
+// exits non-zero if any commit is invalid
+func validateCommitList(repo *git.Repository, commits []*git.Commit) {
+	checker := newHistoryChecker(repo)
+
 	allValid := true
 	validCount := 0
+	ignoredCount := 0
 	totalScore := 0
+	entries := make([]stats.Entry, 0, len(commits))
 
 	for _, commit := range commits {
-		result := linter.Validate(commit.Message)
+		result, reason := checker.check(commit)
+		entries = append(entries, checker.entry(commit, result, reason))
+		if result == nil {
+			ignoredCount++
+			fmt.Printf("⏭️  [%s] %s - %s (ignored: %s)\n",
+				commit.ShortHash, commit.Date, commit.Message, reason)
+			continue
+		}
 
 		status := "✅"
 		if !result.IsValid {
@@ -245,68 +476,353 @@ func handleCommitRange(repo *git.Repository, rangeStr string) {
 
 		totalScore += result.Score
 
-		fmt.Printf("%s [%s] %s - %s\n",
+		exemptions := ""
+		if len(result.Disabled) > 0 {
+			exemptions = " (Lint-Disable: " + strings.Join(result.Disabled, ", ") + ")"
+		}
+
+		fmt.Printf("%s [%s] %s - %s%s\n",
 			status,
 			commit.ShortHash,
 			commit.Date,
-			commit.Message)
+			commit.Message,
+			exemptions)
 	}
 
 	fmt.Println()
 	fmt.Println(strings.Repeat("─", 50))
 
+	linted := len(commits) - ignoredCount
 	avgScore := 0
-	if len(commits) > 0 {
-		avgScore = totalScore / len(commits)
+	validPercent := 0.0
+	if linted > 0 {
+		avgScore = totalScore / linted
+		validPercent = float64(validCount) / float64(linted) * 100
 	}
 
 	fmt.Printf("📈 SUMMARY (%d commits):\n", len(commits))
-	fmt.Printf("   Valid: %d/%d (%.0f%%)\n",
-		validCount, len(commits),
-		float64(validCount)/float64(len(commits))*100)
+	fmt.Printf("   Valid: %d/%d (%.0f%%)\n", validCount, linted, validPercent)
+	if ignoredCount > 0 {
+		fmt.Printf("   Ignored: %d\n", ignoredCount)
+	}
 	fmt.Printf("   Average score: %d/100\n", avgScore)
+	checker.printBaselineSummary()
+	checker.save()
+	reportToForge(entries)
 
 	if !allValid {
 		os.Exit(1)
 	}
 }
 
-func printHelp() {
-	fmt.Println(`
+// loadConfig loads the config in dir, exiting on invalid configuration
+func loadConfig(dir string) *config.Config {
+	cfg, err := config.Load(dir)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	return cfg
+}
+
+// newLinter creates a linter configured by cfg, exiting on an unknown
+// preset
+func newLinter(cfg *config.Config) *linter.Linter {
+	lint, err := buildLinter(cfg)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	return lint
+}
+
+// buildLinter is newLinter for callers that must not exit
+func buildLinter(cfg *config.Config) (*linter.Linter, error) {
+	preset, err := linter.LookupPreset(cfg.Preset)
+	if err != nil {
+		return nil, err
+	}
+
+	scoring := linter.Scoring{
+		Weights:       cfg.Scoring.Weights,
+		ErrorWeight:   cfg.Scoring.ErrorWeight,
+		WarningWeight: cfg.Scoring.WarningWeight,
+		MinScore:      cfg.Scoring.MinScore,
+	}
+	if minScoreOverride >= 0 {
+		scoring.MinScore = minScoreOverride
+	}
+
+	return linter.New(linter.Options{
+		Preset:              preset,
+		Scoring:             scoring,
+		AllowDirectives:     cfg.Directives.Enabled,
+		ForbiddenDirectives: cfg.Directives.Forbidden,
+	}), nil
+}
+
+// configuredPreset returns the preset configured for the current
+// repository, or the default one outside a repository or when the
+// configuration is broken
+func configuredPreset() linter.Preset {
+	dir := "."
+	if repo, err := git.NewRepository(""); err == nil {
+		dir = repo.Path
+	}
+	if cfg, err := config.Load(dir); err == nil {
+		if preset, err := linter.LookupPreset(cfg.Preset); err == nil {
+			return preset
+		}
+	}
+	preset, _ := linter.LookupPreset(linter.DefaultPreset)
+	return preset
+}
+
+// releasePreset returns the configured preset for commands that derive
+// releases from commit types, exiting if its headers have none
+func releasePreset(cfg *config.Config, command string) linter.Preset {
+	preset, err := linter.LookupPreset(cfg.Preset)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	if preset.Release == nil {
+		fmt.Printf("❌ %s needs commit types, which %s headers don't have\n", command, preset.Title)
+		fmt.Println("   Use the conventional, angular or gitmoji preset")
+		os.Exit(1)
+	}
+	return preset
+}
+
+// newIgnoreMatcher compiles the ignore rules of cfg, exiting on invalid
+// patterns
+func newIgnoreMatcher(cfg *config.Config) *ignore.Matcher {
+	matcher, err := ignore.New(cfg.Ignore)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	return matcher
+}
+
+func handleBranch(repo *git.Repository, base string) {
+	baseRef, err := repo.ResolveRef(base)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		fmt.Println("   Fetch it first: git fetch origin " + base)
+		os.Exit(1)
+	}
+
+	head := "HEAD"
+	if branch, err := repo.GetCurrentBranch(); err == nil && branch != "" {
+		head = branch
+	} else if repo.IsDetachedHead() {
+		fmt.Println("ℹ️  HEAD is detached; validating commits reachable from HEAD")
+	}
+
+	commits, mergeBase := commitsSinceMergeBase(repo, baseRef, "HEAD")
+
+	fmt.Printf("📊 Validating commits on %s not in %s (merge base %.7s)...\n\n",
+		head, baseRef, mergeBase)
+
+	if len(commits) == 0 {
+		fmt.Printf("ℹ️  No commits on %s that are not in %s\n", head, baseRef)
+		// Clear a stale result, e.g. after a rebase onto the base
+		reportToForge(nil)
+		return
+	}
+
+	validateCommitList(repo, commits)
+}
+
+// commitsSinceMergeBase returns the commits reachable from head but not from
+// base, along with their merge base
+func commitsSinceMergeBase(repo *git.Repository, base, head string) ([]*git.Commit, string) {
+	mergeBase, err := repo.MergeBase(base, head)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	commits, err := repo.GetCommitsInRange(mergeBase, head)
+	if err != nil {
+		fmt.Printf("❌ Failed to get commits: %v\n", err)
+		os.Exit(1)
+	}
+
+	return commits, mergeBase
+}
+
+func handleCI(repo *git.Repository) {
+	detection := ci.Detect(os.Getenv)
+	if detection == nil {
+		fmt.Println("ℹ️  No CI provider detected; validating the last commit")
+		fmt.Println()
+		handleLastCommits(repo, 1)
+		return
+	}
+
+	fmt.Printf("🤖 Detected CI provider: %s\n", detection.Provider)
+
+	if detection.Base == "" {
+		fmt.Println("ℹ️  Could not determine a commit range; validating the last commit")
+		fmt.Println()
+		handleLastCommits(repo, 1)
+		return
+	}
+
+	// The base may be missing from the checkout: an unfetched branch, a
+	// commit dropped by a force push, or a shallow clone
+	base := detection.Base
+	if detection.BaseIsBranch {
+		ref, err := repo.ResolveRef(base)
+		if err != nil {
+			fmt.Printf("⚠️  %v; validating the last commit\n", err)
+			fmt.Println("   Make sure the base branch is fetched in your CI checkout")
+			fmt.Println()
+			handleLastCommits(repo, 1)
+			return
+		}
+		base = ref
+	}
+
+	mergeBase, err := repo.MergeBase(base, detection.Head)
+	if err != nil {
+		fmt.Printf("⚠️  %v; validating the last commit\n", err)
+		fmt.Println("   Use fetch-depth: 0 in your CI checkout to validate the whole range")
+		fmt.Println()
+		handleLastCommits(repo, 1)
+		return
+	}
+
+	commits, err := repo.GetCommitsInRange(mergeBase, detection.Head)
+	if err != nil {
+		fmt.Printf("❌ Failed to get commits: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("   Range: %.7s..%.7s (from %s)\n\n", mergeBase, detection.Head, detection.Reason)
+
+	if len(commits) == 0 {
+		fmt.Println("ℹ️  No commits found in the detected range")
+		// Clear a stale result, e.g. after a rebase onto the base
+		reportToForge(nil)
+		return
+	}
+
+	validateCommitList(repo, commits)
+}
+
+// printHelp prints usage, with the format and examples of preset
+func printHelp(preset linter.Preset) {
+	fmt.Printf(`
 🌳 COMMIT MESSAGE LINTER v2.0
 ─────────────────────────────
 
-Validate commit messages against Conventional Commits standard.
+Validate commit messages against the %s format: %s
+`, preset.Title, preset.Format)
 
+	fmt.Println(`
 BASIC USAGE:
   commit-lint "feat(auth): add login functionality"
   commit-lint --file .git/COMMIT_EDITMSG
+  commit-lint --json "fix: typo"     Machine-readable result
+  commit-lint --min-score 80 ...     Also fail messages scoring below 80
+
+PRESETS:
+  Set "preset" in .commitlint.json to switch commit conventions:
+    conventional (default), angular, gitmoji, jira, kernel
+
+DISABLING RULES:
+  Add a trailer to the commit message to exempt it from specific rules:
+    Lint-Disable: description-max-length, imperative-mood
 
 GIT INTEGRATION:
   commit-lint --install              Install Git commit-msg hook
-  commit-lint --install --force      Force install (overwrite)
+  commit-lint --install --force      Update an existing commit-lint block
+  commit-lint --install --missing-binary warn
+                                     Let commits through if the binary
+                                     disappears (default: fail)
   commit-lint --uninstall            Uninstall hook
   commit-lint --check                Check if hook is installed
+  commit-lint doctor                 Diagnose hook installation problems
+  commit-lint --install --hook pre-push
+                                     Validate commits at push time instead
+  commit-lint --install --target lefthook
+                                     Add to a hook manager's config
+                                     (pre-commit, lefthook, husky; the
+                                     default "auto" detects them)
 
 VALIDATE HISTORY:
   commit-lint --last                 Validate last commit
   commit-lint --last --count 5       Validate last 5 commits
   commit-lint --range HEAD~3..HEAD   Validate commits in range
+  commit-lint --branch main          Validate commits not yet in main
+  commit-lint --ci                   Validate the PR/push range detected
+                                     from GitHub Actions, GitLab CI,
+                                     Jenkins, Buildkite, CircleCI or Azure
+  commit-lint --range ... --no-cache Re-lint instead of reusing cached
+                                     results (cache clear deletes them)
+  commit-lint --range ... --write-notes
+                                     Record results as git notes in
+                                     refs/notes/commit-lint
+  commit-lint --ci --report-to auto  Post a red/green commit status and a
+                                     PR comment (GitHub, GitLab, Gitea;
+                                     token from $GITHUB_TOKEN etc.)
+
+PULL REQUESTS:
+  commit-lint pr --title "feat: add login" --body "..."
+  commit-lint pr --event $GITHUB_EVENT_PATH --format github
+                                     Lint the title/description that a
+                                     squash merge turns into a commit
+
+SERVER HOOKS:
+  commit-lint pre-receive            Validate pushed commits (stdin)
+  commit-lint update <ref> <old> <new>
+                                     Validate one ref update
+  commit-lint serve --secret ... --callback https://ci.example.com/hook
+                                     Lint GitHub, GitLab and Gitea push and
+                                     pull request webhooks (POST /webhook)
+
+REPORTS:
+  commit-lint stats                  Commit-quality statistics for history
+  commit-lint stats --range v1.0.0..HEAD --bucket week --format csv
+  commit-lint stats --format prometheus --output commitlint.prom
+  commit-lint report --html out.html Self-contained HTML history audit
+  commit-lint scan ~/src --since "90 days ago"
+                                     Rank every repository below ~/src
+                                     by compliance (bare mirrors too)
+
+BASELINE:
+  commit-lint baseline create        Record existing violations so history
+                                     checks only fail on new ones
+
+RELEASES:
+  commit-lint changelog --from v1.2.0 --to HEAD
+                                     Print a Markdown changelog
+  commit-lint changelog --from v1.2.0 --output CHANGELOG.md
+                                     Prepend it to CHANGELOG.md
+  commit-lint next-version           Print the next semantic version
+  commit-lint next-version --pre rc --json
+                                     Next release candidate, explained
+
+EXAMPLES:`)
+	for _, example := range preset.Examples {
+		fmt.Println("  • " + example)
+	}
 
-EXAMPLES:
-  • feat: add new feature
-  • fix: resolve bug
-  • docs: update documentation
-  • style: code formatting
-  • refactor: code restructuring
-  • test: add tests
-  • chore: maintenance tasks
-
+	fmt.Print(`
 GIT HOOK FEATURES:
   • Automatically validates every commit
+  • Honors core.hooksPath and chains into existing hooks
   • Prevents invalid commits
   • Shows helpful suggestions
-  • Can be bypassed with --no-verify
+  • Calls the binary that installed it (falls back to PATH)
+`)
 
-Learn more: https://www.conventionalcommits.org/`)
+	if preset.URL != "" {
+		fmt.Println()
+		fmt.Println("Learn more: " + preset.URL)
+	}
 }
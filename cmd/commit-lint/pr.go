@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"commit-linter/internal/git"
+	"commit-linter/internal/linter"
+)
+
+// pullRequestEvent holds the parts of a GitHub pull_request event we need
+type pullRequestEvent struct {
+	PullRequest struct {
+		Title string `json:"title"`
+		Body  string `json:"body"`
+	} `json:"pull_request"`
+}
+
+func handlePullRequest(args []string) {
+	fs := flag.NewFlagSet("pr", flag.ExitOnError)
+	title := fs.String("title", "", "Pull request title")
+	body := fs.String("body", "", "Pull request description")
+	fromStdin := fs.Bool("stdin", false, "Read the title (first line) and body from stdin")
+	eventPath := fs.String("event", "", "GitHub event JSON file (defaults to $GITHUB_EVENT_PATH)")
+	format := fs.String("format", "text", "Output format: text or github (workflow annotations)")
+	fs.Parse(args)
+
+	switch {
+	case *title != "":
+		// Flags take precedence
+	case *fromStdin:
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			fmt.Printf("❌ Failed to read stdin: %v\n", err)
+			os.Exit(1)
+		}
+		parts := strings.SplitN(normalizeNewlines(string(data)), "\n", 2)
+		*title = parts[0]
+		if len(parts) > 1 {
+			*body = parts[1]
+		}
+	default:
+		if *eventPath == "" {
+			*eventPath = os.Getenv("GITHUB_EVENT_PATH")
+		}
+		if *eventPath == "" {
+			fmt.Println("❌ No pull request provided")
+			fmt.Println("   Use --title/--body, --stdin or --event <file>")
+			os.Exit(1)
+		}
+
+		event, err := readPullRequestEvent(*eventPath)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+		*title, *body = event.PullRequest.Title, event.PullRequest.Body
+	}
+
+	// A squash merge produces "title\n\nbody", so lint exactly that
+	message := strings.TrimSpace(*title)
+	if b := strings.TrimSpace(normalizeNewlines(*body)); b != "" {
+		message += "\n\n" + b
+	}
+
+	// The config lives in the repository root when run from a checkout
+	configDir := "."
+	if repo, err := git.NewRepository(""); err == nil {
+		configDir = repo.Path
+	}
+	result := newLinter(loadConfig(configDir)).Validate(message)
+	result.Suppress(linter.PullRequestExemptRules())
+
+	if *format == "github" {
+		printGitHubAnnotations(result)
+	} else {
+		printPullRequestResult(strings.TrimSpace(*title), result)
+	}
+
+	if !result.IsValid {
+		os.Exit(1)
+	}
+}
+
+func readPullRequestEvent(path string) (*pullRequestEvent, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read event file: %v", err)
+	}
+
+	var event pullRequestEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		return nil, fmt.Errorf("failed to parse event file: %v", err)
+	}
+	if event.PullRequest.Title == "" {
+		return nil, fmt.Errorf("event file %s is not a pull_request event", path)
+	}
+
+	return &event, nil
+}
+
+// normalizeNewlines converts CRLF line endings, as sent by browsers, to LF
+func normalizeNewlines(s string) string {
+	return strings.ReplaceAll(s, "\r\n", "\n")
+}
+
+// violationField names the part of the pull request a violation refers to
+func violationField(v linter.Violation) string {
+	if linter.IsBodyRule(v.Rule) {
+		return "description"
+	}
+	return "title"
+}
+
+// printPullRequestResult prints a compact report suitable for a status check
+func printPullRequestResult(title string, result *linter.ValidationResult) {
+	if result.IsValid {
+		fmt.Printf("✅ PR title passes: %s (score %d/100)\n", title, result.Score)
+	} else {
+		fmt.Printf("❌ PR title fails: %s (score %d/100)\n", title, result.Score)
+	}
+
+	for _, v := range result.Violations {
+		icon := "⚠️ "
+		if v.Level == "error" {
+			icon = "❌"
+		}
+		fmt.Printf("  %s %s: %s [%s]\n", icon, violationField(v), v.Message, v.Rule)
+	}
+}
+
+// printGitHubAnnotations emits GitHub Actions workflow commands so
+// violations show up as annotations on the check run
+func printGitHubAnnotations(result *linter.ValidationResult) {
+	for _, v := range result.Violations {
+		level := "warning"
+		if v.Level == "error" {
+			level = "error"
+		}
+		fmt.Printf("::%s title=commit-lint (%s)::%s [%s]\n",
+			level, violationField(v), v.Message, v.Rule)
+	}
+
+	if result.IsValid {
+		fmt.Printf("::notice title=commit-lint::PR title passes (score %d/100)\n", result.Score)
+	}
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"commit-linter/internal/git"
+)
+
+// handlePreReceive implements the server-side pre-receive hook. Git passes
+// one "<old> <new> <ref>" line per updated ref on stdin; a non-zero exit
+// rejects the whole push.
+func handlePreReceive(args []string) {
+	updates, err := git.ParseRefUpdates(os.Stdin)
+	if err != nil {
+		fmt.Printf("commit-lint: %v\n", err)
+		os.Exit(1)
+	}
+
+	checkRefUpdates(updates)
+}
+
+// handleUpdateHook implements the server-side update hook, which Git runs
+// once per ref with "<ref> <old> <new>" as arguments
+func handleUpdateHook(args []string) {
+	if len(args) != 3 {
+		fmt.Println("commit-lint: usage: commit-lint update <ref> <old> <new>")
+		os.Exit(1)
+	}
+
+	checkRefUpdates([]git.RefUpdate{{Ref: args[0], OldSHA: args[1], NewSHA: args[2]}})
+}
+
+// checkRefUpdates validates the new commits of each branch update and exits
+// non-zero with a per-commit report if any are invalid. Output is plain
+// text because Git relays it to the pusher prefixed with "remote:".
+func checkRefUpdates(updates []git.RefUpdate) {
+	repo, err := git.NewRepository("")
+	if err != nil {
+		fmt.Printf("commit-lint: %v\n", err)
+		os.Exit(1)
+	}
+
+	cfg := loadConfig(repo.Path)
+	lint := newLinter(cfg)
+	matcher := newIgnoreMatcher(cfg)
+
+	rejected := 0
+	checked := 0
+	ignored := 0
+	// A push can bring the same commits on several branches; each is
+	// reported once, under the first branch
+	seen := map[string]bool{}
+
+	for _, update := range updates {
+		if update.IsDelete() || !update.IsBranch() {
+			continue
+		}
+
+		commits, err := repo.GetPushedCommits(update)
+		if err != nil {
+			fmt.Printf("commit-lint: %v\n", err)
+			os.Exit(1)
+		}
+
+		for _, commit := range commits {
+			if seen[commit.Hash] {
+				continue
+			}
+			seen[commit.Hash] = true
+
+			if _, ok := matcher.Match(commit); ok {
+				ignored++
+				continue
+			}
+
+			checked++
+			result := lint.Validate(commit.FullMessage())
+			if result.IsValid {
+				continue
+			}
+
+			if rejected == 0 {
+				fmt.Println("commit-lint: push rejected, invalid commit messages:")
+				fmt.Println()
+			}
+			rejected++
+
+			fmt.Printf("  %s %s (%s)\n", commit.ShortHash, commit.Message,
+				strings.TrimPrefix(update.Ref, "refs/heads/"))
+			for _, v := range result.Violations {
+				if v.Level == "error" {
+					fmt.Printf("      - %s\n", v.Message)
+				}
+			}
+		}
+	}
+
+	if rejected > 0 {
+		fmt.Println()
+		fmt.Printf("commit-lint: %d of %d new commit(s) failed validation (%d ignored).\n",
+			rejected, checked, ignored)
+		fmt.Println("commit-lint: reword them with 'git rebase -i' and push again.")
+		preset := lint.Preset()
+		fmt.Printf("commit-lint: format: %s, e.g. %s\n", preset.Format, preset.Examples[0])
+		os.Exit(1)
+	}
+}
+
+// handlePrePush implements the client-side pre-push hook. Git passes the
+// remote name and URL as arguments and one line per pushed ref on stdin.
+func handlePrePush(args []string) {
+	remote := "origin"
+	if len(args) > 0 {
+		remote = args[0]
+	}
+
+	updates, err := git.ParsePushUpdates(os.Stdin)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	repo, err := git.NewRepository("")
+	if err != nil {
+		fmt.Printf("❌ Not a Git repository: %v\n", err)
+		os.Exit(1)
+	}
+
+	cfg := loadConfig(repo.Path)
+	lint := newLinter(cfg)
+	matcher := newIgnoreMatcher(cfg)
+
+	// oldest holds each ref's oldest invalid commit, where rewording starts
+	var refs []string
+	oldest := map[string]*git.Commit{}
+	invalid := 0
+	checked := 0
+	// valid remembers commits pushed on an earlier ref, so each is
+	// reported once but still counts for every ref's rebase hint
+	valid := map[string]bool{}
+	// git log lists newest first, so later offenders are older
+	markInvalid := func(ref string, commit *git.Commit) {
+		if oldest[ref] == nil {
+			refs = append(refs, ref)
+		}
+		oldest[ref] = commit
+	}
+
+	for _, update := range updates {
+		commits, err := repo.GetUnpushedCommits(remote, update)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+
+		for _, commit := range commits {
+			if ok, seen := valid[commit.Hash]; seen {
+				if !ok {
+					markInvalid(update.LocalRef, commit)
+				}
+				continue
+			}
+
+			if reason, ok := matcher.Match(commit); ok {
+				valid[commit.Hash] = true
+				fmt.Printf("⏭️  [%s] %s (ignored: %s)\n", commit.ShortHash, commit.Message, reason)
+				continue
+			}
+
+			checked++
+			result := lint.Validate(commit.FullMessage())
+			valid[commit.Hash] = result.IsValid
+			if result.IsValid {
+				continue
+			}
+
+			markInvalid(update.LocalRef, commit)
+			invalid++
+			fmt.Printf("❌ [%s] %s\n", commit.ShortHash, commit.Message)
+			for _, v := range result.Violations {
+				if v.Level == "error" {
+					fmt.Printf("     • %s\n", v.Message)
+				}
+			}
+		}
+	}
+
+	if invalid == 0 {
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(strings.Repeat("─", 50))
+	fmt.Printf("🚫 Push blocked: %d of %d unpushed commit(s) have invalid messages\n",
+		invalid, checked)
+	fmt.Println()
+	fmt.Println("To fix them, reword the commits and push again:")
+	for _, ref := range refs {
+		if len(refs) > 1 {
+			fmt.Printf("  git checkout %s\n", strings.TrimPrefix(ref, "refs/heads/"))
+		}
+		if commit := oldest[ref]; len(commit.Parents) == 0 {
+			fmt.Println("  git rebase -i --root")
+		} else {
+			fmt.Printf("  git rebase -i %s~1\n", commit.ShortHash)
+		}
+	}
+	fmt.Println("  # change 'pick' to 'reword' for each commit listed above")
+	fmt.Println()
+	fmt.Println("To bypass (not recommended): git push --no-verify")
+	os.Exit(1)
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"commit-linter/internal/git"
+	"commit-linter/internal/report"
+	"commit-linter/internal/stats"
+)
+
+func handleReport(args []string) {
+	fs := flag.NewFlagSet("report", flag.ExitOnError)
+	output := fs.String("html", "", "Write a self-contained HTML report to this file")
+	rangeStr := fs.String("range", "", "Report on commits in range (default: full history)")
+	bucket := fs.String("bucket", stats.BucketMonth, "Trend bucket: week or month")
+	fs.BoolVar(&noCache, "no-cache", false, "Don't read or write the result cache")
+	fs.Parse(args)
+
+	if *output == "" {
+		fmt.Println("❌ --html is required")
+		fmt.Println("   Example: commit-lint report --html commit-report.html")
+		os.Exit(1)
+	}
+
+	repo, err := git.NewRepository("")
+	if err != nil {
+		fmt.Printf("❌ Not a Git repository: %v\n", err)
+		os.Exit(1)
+	}
+
+	commits := historyCommits(repo, *rangeStr)
+	checker := newHistoryChecker(repo)
+	entries := checker.checkAll(commits)
+	checker.save()
+
+	description := "Full history"
+	if *rangeStr != "" {
+		description = "Range " + *rangeStr
+	}
+
+	f, err := os.Create(*output)
+	if err != nil {
+		fmt.Printf("❌ Failed to create %s: %v\n", *output, err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	err = report.WriteHTML(f, entries, report.Options{
+		Title:  "Commit report: " + filepath.Base(repo.Path),
+		Range:  description,
+		Bucket: *bucket,
+		Preset: checker.linter.Preset(),
+	})
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("✅ Wrote report for %d commits to %s\n", len(commits), *output)
+}
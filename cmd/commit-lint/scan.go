@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+
+	"commit-linter/internal/formatter"
+	"commit-linter/internal/git"
+	"commit-linter/internal/stats"
+)
+
+func handleScan(args []string) {
+	fs := flag.NewFlagSet("scan", flag.ExitOnError)
+	since := fs.String("since", "", "Only lint commits after this date (e.g. 2026-01-01, \"90 days ago\")")
+	maxCount := fs.Int("max-count", 500, "Lint at most this many recent commits per repository (0 for all)")
+	ref := fs.String("ref", "HEAD", "Branch or ref to lint in each repository")
+	depth := fs.Int("depth", 3, "How many directories deep to look for repositories")
+	jobs := fs.Int("jobs", runtime.NumCPU(), "Repositories to scan in parallel")
+	format := fs.String("format", "text", "Output format: text, json or csv")
+	fs.BoolVar(&noCache, "no-cache", false, "Don't read or write the result cache")
+	fs.Parse(args)
+
+	roots := fs.Args()
+	if len(roots) == 0 {
+		fmt.Println("Usage: commit-lint scan [flags] <dir>...")
+		os.Exit(1)
+	}
+	if *format != "text" && *format != "json" && *format != "csv" {
+		fmt.Printf("❌ Invalid --format %q. Use: text, json, csv\n", *format)
+		os.Exit(1)
+	}
+	if *jobs < 1 {
+		*jobs = 1
+	}
+
+	var targets []stats.RepoScan
+	for _, root := range roots {
+		paths, err := git.DiscoverRepositories(root, *depth)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+		for _, path := range paths {
+			abs, err := filepath.Abs(path)
+			if err != nil {
+				abs = path
+			}
+			targets = append(targets, stats.RepoScan{Name: repoName(root, path), Path: abs})
+		}
+	}
+	if len(targets) == 0 {
+		fmt.Println("ℹ️  No Git repositories found")
+		return
+	}
+	if *format == "text" {
+		fmt.Printf("🔍 Scanning %d repositories...\n", len(targets))
+	}
+
+	// Each worker fills in its own slots, so no locking is needed
+	work := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < *jobs; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range work {
+				scanRepository(&targets[idx], *ref, *since, *maxCount)
+			}
+		}()
+	}
+	for idx := range targets {
+		work <- idx
+	}
+	close(work)
+	wg.Wait()
+
+	report := stats.Scan(targets, stats.BucketMonth)
+
+	switch *format {
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(report)
+	case "csv":
+		if err := report.WriteCSV(os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			os.Exit(1)
+		}
+	default:
+		formatter.PrintScan(report)
+	}
+}
+
+// scanRepository lints the recent history of one repository with its own
+// configuration
+func scanRepository(target *stats.RepoScan, ref, since string, maxCount int) {
+	repo, err := git.NewRepository(target.Path)
+	if err != nil {
+		target.Err = err
+		return
+	}
+	target.Bare = repo.IsBare
+
+	checker, err := buildLintChecker(repo)
+	if err != nil {
+		target.Err = err
+		return
+	}
+
+	commits, err := repo.GetRecentCommits(ref, since, maxCount)
+	if err != nil {
+		target.Err = fmt.Errorf("no history at %s: %v", ref, err)
+		return
+	}
+
+	target.Entries = checker.checkAll(commits)
+	checker.save()
+}
+
+// repoName identifies a discovered repository by its path below the
+// scanned directory
+func repoName(root, path string) string {
+	name, err := filepath.Rel(root, path)
+	if err != nil || name == "." {
+		abs, _ := filepath.Abs(path)
+		return filepath.Base(abs)
+	}
+	return filepath.ToSlash(name)
+}
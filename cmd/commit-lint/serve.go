@@ -0,0 +1,54 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"commit-linter/internal/config"
+	"commit-linter/internal/webhook"
+)
+
+func handleServe(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", ":8080", "Address to listen on")
+	secret := fs.String("secret", os.Getenv("COMMIT_LINT_WEBHOOK_SECRET"), "Webhook secret (default $COMMIT_LINT_WEBHOOK_SECRET)")
+	callback := fs.String("callback", "", "URL that receives each result as a JSON POST")
+	configDir := fs.String("config-dir", ".", "Directory containing "+config.FileName)
+	insecure := fs.Bool("insecure", false, "Accept deliveries without verifying a secret (local testing only)")
+	fs.Parse(args)
+
+	if *secret == "" && !*insecure {
+		fmt.Println("❌ A webhook secret is required to verify deliveries")
+		fmt.Println("   Use --secret or $COMMIT_LINT_WEBHOOK_SECRET (or --insecure for local testing)")
+		os.Exit(1)
+	}
+
+	cfg := loadConfig(*configDir)
+	logger := log.New(os.Stderr, "commit-lint: ", log.LstdFlags)
+
+	server := &webhook.Server{
+		Secret:   *secret,
+		Callback: *callback,
+		Linter:   newLinter(cfg),
+		Matcher:  newIgnoreMatcher(cfg),
+		Logger:   logger,
+	}
+
+	httpServer := &http.Server{
+		Addr:              *addr,
+		Handler:           server.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	logger.Printf("listening on %s (POST /webhook)", *addr)
+	if *secret == "" {
+		logger.Printf("warning: deliveries are not verified (--insecure)")
+	}
+	if err := httpServer.ListenAndServe(); err != nil {
+		logger.Fatal(err)
+	}
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"commit-linter/internal/formatter"
+	"commit-linter/internal/git"
+	"commit-linter/internal/stats"
+)
+
+func handleStats(args []string) {
+	fs := flag.NewFlagSet("stats", flag.ExitOnError)
+	rangeStr := fs.String("range", "", "Analyze commits in range (default: full history)")
+	bucket := fs.String("bucket", stats.BucketMonth, "Trend bucket: week or month")
+	format := fs.String("format", "text", "Output format: text, json, csv, prometheus or openmetrics")
+	output := fs.String("output", "", "Write to this file instead of stdout (replaced atomically)")
+	var labels labelFlags
+	fs.Var(&labels, "label", "Extra metric label as name=value (repeatable)")
+	fs.BoolVar(&noCache, "no-cache", false, "Don't read or write the result cache")
+	fs.Parse(args)
+
+	if *bucket != stats.BucketWeek && *bucket != stats.BucketMonth {
+		fmt.Printf("❌ Invalid --bucket %q. Use: week, month\n", *bucket)
+		os.Exit(1)
+	}
+
+	repo, err := git.NewRepository("")
+	if err != nil {
+		fmt.Printf("❌ Not a Git repository: %v\n", err)
+		os.Exit(1)
+	}
+
+	commits := historyCommits(repo, *rangeStr)
+	checker := newLintChecker(repo)
+	report := stats.Summarize(checker.checkAll(commits), *bucket)
+	checker.save()
+
+	var write func(w io.Writer) error
+	switch *format {
+	case "json":
+		write = func(w io.Writer) error {
+			enc := json.NewEncoder(w)
+			enc.SetIndent("", "  ")
+			return enc.Encode(report)
+		}
+	case "csv":
+		write = report.WriteCSV
+	case stats.FormatPrometheus, stats.FormatOpenMetrics:
+		metricLabels := map[string]string{"repo": filepath.Base(repo.Path)}
+		for name, value := range labels {
+			metricLabels[name] = value
+		}
+		write = func(w io.Writer) error {
+			return report.WriteMetrics(w, *format, metricLabels)
+		}
+	case "text":
+		if *output != "" {
+			fmt.Println("❌ --output is not supported with --format text")
+			os.Exit(1)
+		}
+		formatter.PrintStats(report)
+		return
+	default:
+		fmt.Printf("❌ Invalid --format %q. Use: text, json, csv, prometheus, openmetrics\n", *format)
+		os.Exit(1)
+	}
+
+	if *output == "" {
+		err = write(os.Stdout)
+	} else {
+		err = writeFileAtomic(*output, write)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// writeFileAtomic writes to a temporary file next to path and renames it
+// into place, so collectors such as the node exporter's textfile collector
+// never read a half-written file
+func writeFileAtomic(path string, write func(w io.Writer) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %v", path, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := write(tmp); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace %s: %v", path, err)
+	}
+	return nil
+}
+
+// labelFlags collects repeated --label name=value flags
+type labelFlags map[string]string
+
+func (l *labelFlags) String() string {
+	return fmt.Sprint(map[string]string(*l))
+}
+
+func (l *labelFlags) Set(value string) error {
+	name, val, ok := strings.Cut(value, "=")
+	if !ok || name == "" {
+		return fmt.Errorf("expected name=value, got %q", value)
+	}
+	if err := stats.CheckLabelName(name); err != nil {
+		return err
+	}
+	if *l == nil {
+		*l = labelFlags{}
+	}
+	(*l)[name] = val
+	return nil
+}
+
+// historyCommits returns the commits in a from..to range, or the full
+// history of HEAD when the range is empty
+func historyCommits(repo *git.Repository, rangeStr string) []*git.Commit {
+	from, to := "", "HEAD"
+	if rangeStr != "" {
+		parts := strings.Split(rangeStr, "..")
+		if len(parts) != 2 {
+			fmt.Println("❌ Invalid range format. Use: from..to")
+			fmt.Println("   Example: HEAD~3..HEAD")
+			os.Exit(1)
+		}
+		from, to = parts[0], parts[1]
+	}
+
+	commits, err := repo.GetCommitsSince(from, to)
+	if err != nil {
+		fmt.Printf("❌ Failed to get commits: %v\n", err)
+		os.Exit(1)
+	}
+	return commits
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"commit-linter/internal/git"
+	"commit-linter/internal/semver"
+)
+
+// exitNoRelease is the next-version exit code when no commit since the
+// latest release requires a new one
+const exitNoRelease = 2
+
+// nextVersionReport is the JSON form of the next-version output
+type nextVersionReport struct {
+	Current string          `json:"current"`
+	Next    string          `json:"next"` // empty when no release is needed
+	Bump    semver.Level    `json:"bump"`
+	Commits []semver.Reason `json:"commits"`
+}
+
+func handleNextVersion(args []string) {
+	fs := flag.NewFlagSet("next-version", flag.ExitOnError)
+	prefix := fs.String("tag-prefix", "v", "Prefix of release tags")
+	channel := fs.String("pre", "", "Pre-release channel (e.g., rc, beta)")
+	zeroMode := fs.String("zero-mode", string(semver.ZeroShift),
+		"0.x handling: shift (breaking bumps minor) or strict (breaking releases 1.0.0)")
+	asJSON := fs.Bool("json", false, "Explain the bump as JSON")
+	fs.Parse(args)
+
+	mode := semver.ZeroMode(*zeroMode)
+	if mode != semver.ZeroShift && mode != semver.ZeroStrict {
+		fmt.Printf("❌ Invalid --zero-mode %q. Use: shift, strict\n", *zeroMode)
+		os.Exit(1)
+	}
+
+	repo, err := git.NewRepository("")
+	if err != nil {
+		fmt.Printf("❌ Not a Git repository: %v\n", err)
+		os.Exit(1)
+	}
+
+	preset := releasePreset(loadConfig(repo.Path), "next-version")
+
+	tags, err := repo.GetTagsMerged("HEAD")
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	// The bump is computed against the latest stable release; the latest
+	// tag overall is only used to continue a pre-release series
+	stableTag, stable, released := semver.Latest(tags, *prefix, false)
+	_, latest, _ := semver.Latest(tags, *prefix, true)
+
+	commits, err := repo.GetCommitsSince(stableTag, "HEAD")
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	report := nextVersionReport{Current: stableTag, Commits: []semver.Reason{}}
+	for _, c := range commits {
+		reason := semver.CommitLevel(c, preset)
+		if reason.Bump > report.Bump {
+			report.Bump = reason.Bump
+		}
+		report.Commits = append(report.Commits, reason)
+	}
+
+	if report.Bump > semver.None {
+		next := semver.Initial
+		if released {
+			next = semver.Apply(stable, report.Bump, mode)
+		}
+		if *channel != "" {
+			next = semver.WithChannel(next, latest, *channel)
+		}
+		report.Next = *prefix + next.String()
+	}
+
+	switch {
+	case *asJSON:
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(report)
+	case report.Bump == semver.None:
+		fmt.Fprintf(os.Stderr, "ℹ️  No release needed: no release-worthy commits since %s\n", displayTag(stableTag))
+	case !released:
+		fmt.Fprintf(os.Stderr, "📦 First release (%d commits)\n", len(commits))
+		fmt.Println(report.Next)
+	default:
+		fmt.Fprintf(os.Stderr, "📦 %s bump from %s (%d commits)\n",
+			report.Bump, displayTag(stableTag), len(commits))
+		fmt.Println(report.Next)
+	}
+
+	if report.Bump == semver.None {
+		os.Exit(exitNoRelease)
+	}
+}
+
+// displayTag names the base release for messages
+func displayTag(tag string) string {
+	if tag == "" {
+		return "initial commit"
+	}
+	return tag
+}
@@ -0,0 +1,158 @@
+package baseline
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"commit-linter/internal/linter"
+)
+
+// FileName is the baseline file looked up in the repository root
+const FileName = ".commitlint-baseline.json"
+
+// formatVersion is bumped if the file layout changes
+const formatVersion = 1
+
+// Baseline records known violations in existing history, keyed by commit
+// hash, so that only new violations fail history checks
+type Baseline struct {
+	Version    int                 `json:"version"`
+	Violations map[string][]string `json:"violations"`
+
+	// used tracks which entries suppressed something during this run
+	used map[string]map[string]bool
+	// checked tracks which baselined commits were validated during this run
+	checked map[string]bool
+}
+
+// Entry is a single baselined violation
+type Entry struct {
+	Hash string
+	Rule string
+}
+
+// New returns an empty baseline
+func New() *Baseline {
+	return &Baseline{
+		Version:    formatVersion,
+		Violations: map[string][]string{},
+		used:       map[string]map[string]bool{},
+		checked:    map[string]bool{},
+	}
+}
+
+// Path returns the baseline location for a repository root
+func Path(dir string) string {
+	return filepath.Join(dir, FileName)
+}
+
+// Load reads the baseline from path. A missing file yields an empty
+// baseline.
+func Load(path string) (*Baseline, error) {
+	b := New()
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return b, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline: %v", err)
+	}
+
+	if err := json.Unmarshal(data, b); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", path, err)
+	}
+	if b.Version != formatVersion {
+		return nil, fmt.Errorf("%s has unsupported version %d", path, b.Version)
+	}
+	if b.Violations == nil {
+		b.Violations = map[string][]string{}
+	}
+
+	return b, nil
+}
+
+// Save writes the baseline as stable, diff-friendly JSON
+func (b *Baseline) Save(path string) error {
+	for hash := range b.Violations {
+		sort.Strings(b.Violations[hash])
+	}
+
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write baseline: %v", err)
+	}
+	return nil
+}
+
+// Set records all violations of a result for the commit, replacing what
+// was recorded before. A result without violations removes the commit.
+func (b *Baseline) Set(hash string, result *linter.ValidationResult) {
+	delete(b.Violations, hash)
+	for _, v := range result.Violations {
+		b.Violations[hash] = append(b.Violations[hash], v.Rule)
+	}
+}
+
+// Len returns the number of baselined violations
+func (b *Baseline) Len() int {
+	n := 0
+	for _, rules := range b.Violations {
+		n += len(rules)
+	}
+	return n
+}
+
+// Apply removes the baselined violations of a commit from its result and
+// returns the suppressed violations
+func (b *Baseline) Apply(hash string, result *linter.ValidationResult) []linter.Violation {
+	rules, ok := b.Violations[hash]
+	if !ok {
+		return nil
+	}
+	b.checked[hash] = true
+
+	allowed := map[string]bool{}
+	for _, rule := range rules {
+		allowed[rule] = true
+	}
+
+	suppressed := result.Suppress(allowed)
+	for _, v := range suppressed {
+		if b.used[hash] == nil {
+			b.used[hash] = map[string]bool{}
+		}
+		b.used[hash][v.Rule] = true
+	}
+
+	return suppressed
+}
+
+// Stale returns entries for commits validated during this run whose
+// baselined rule no longer fires, e.g. after a rule was relaxed. Entries of
+// commits outside the checked range are not reported.
+func (b *Baseline) Stale() []Entry {
+	var stale []Entry
+	for hash := range b.checked {
+		for _, rule := range b.Violations[hash] {
+			if !b.used[hash][rule] {
+				stale = append(stale, Entry{Hash: hash, Rule: rule})
+			}
+		}
+	}
+
+	sort.Slice(stale, func(i, j int) bool {
+		if stale[i].Hash != stale[j].Hash {
+			return stale[i].Hash < stale[j].Hash
+		}
+		return stale[i].Rule < stale[j].Rule
+	})
+	return stale
+}
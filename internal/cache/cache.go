@@ -0,0 +1,150 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"commit-linter/internal/linter"
+)
+
+// formatVersion is bumped if the file layout changes
+const formatVersion = 1
+
+// Entry is the cached outcome of checking one commit
+type Entry struct {
+	// Ignored is the reason the commit was skipped by ignore rules
+	Ignored string           `json:"ignored,omitempty"`
+	Result  *linter.Snapshot `json:"result,omitempty"`
+}
+
+// Cache stores lint results per commit hash. Commits are immutable, so an
+// entry stays valid as long as the rules and the tool that produced it are
+// unchanged; the fingerprint captures both.
+type Cache struct {
+	Version     int              `json:"version"`
+	Fingerprint string           `json:"fingerprint"`
+	Entries     map[string]Entry `json:"entries"`
+
+	path  string
+	dirty bool
+}
+
+// Dir returns the commit-lint directory inside a Git common dir, shared by
+// all worktrees of a repository
+func Dir(commonDir string) string {
+	return filepath.Join(commonDir, "commit-lint")
+}
+
+// Path returns the cache file for a Git common dir
+func Path(commonDir string) string {
+	return filepath.Join(Dir(commonDir), "cache.json")
+}
+
+// Fingerprint hashes everything that affects lint results
+func Fingerprint(parts ...interface{}) string {
+	h := sha256.New()
+	for _, part := range parts {
+		data, _ := json.Marshal(part)
+		h.Write(data)
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Load reads the cache at path. A missing or unreadable file, or one
+// written with a different fingerprint, yields an empty cache: the cache
+// is only an optimization and never fails a run.
+func Load(path, fingerprint string) *Cache {
+	empty := &Cache{
+		Version:     formatVersion,
+		Fingerprint: fingerprint,
+		Entries:     map[string]Entry{},
+		path:        path,
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return empty
+	}
+
+	c := &Cache{}
+	if err := json.Unmarshal(data, c); err != nil {
+		return empty
+	}
+	if c.Version != formatVersion || c.Fingerprint != fingerprint || c.Entries == nil {
+		return empty
+	}
+
+	c.path = path
+	return c
+}
+
+// Get returns the cached entry for a commit
+func (c *Cache) Get(hash string) (Entry, bool) {
+	e, ok := c.Entries[hash]
+	return e, ok
+}
+
+// Put records the entry for a commit
+func (c *Cache) Put(hash string, e Entry) {
+	c.Entries[hash] = e
+	c.dirty = true
+}
+
+// Len returns the number of cached commits
+func (c *Cache) Len() int {
+	return len(c.Entries)
+}
+
+// Save writes the cache if it changed. The file is replaced atomically so
+// concurrent runs never read a partial cache.
+func (c *Cache) Save() error {
+	if !c.dirty {
+		return nil
+	}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache: %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %v", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), ".cache-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to write cache: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write cache: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write cache: %v", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path); err != nil {
+		return fmt.Errorf("failed to write cache: %v", err)
+	}
+
+	c.dirty = false
+	return nil
+}
+
+// Clear deletes the cache file and reports whether one existed
+func Clear(path string) (bool, error) {
+	err := os.Remove(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to remove cache: %v", err)
+	}
+	return true, nil
+}
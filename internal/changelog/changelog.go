@@ -0,0 +1,218 @@
+package changelog
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"commit-linter/internal/config"
+	"commit-linter/internal/git"
+	"commit-linter/internal/linter"
+)
+
+// breakingTitle is the section title used for breaking changes
+const breakingTitle = "BREAKING CHANGES"
+
+// issueRefRe matches issue references such as #123
+var issueRefRe = regexp.MustCompile(`(^|[\s(])#(\d+)\b`)
+
+// Options controls changelog generation
+type Options struct {
+	// Version is the heading of the release (e.g. v1.3.0)
+	Version string
+	// Date is printed next to the version
+	Date string
+	// RepoURL is used to link commit hashes and issues; empty disables links
+	RepoURL string
+	// Config provides section titles and hidden types
+	Config config.ChangelogConfig
+	// Preset parses the commits and provides the default sections; it must
+	// have a Release
+	Preset linter.Preset
+}
+
+// Entry is a single changelog line
+type Entry struct {
+	Commit *git.Commit
+	Parsed *linter.CommitMessage
+}
+
+// Generate renders a Markdown changelog for the given commits. Commits that
+// do not follow the preset's format are skipped and returned so the caller
+// can report them.
+func Generate(commits []*git.Commit, opts Options) (string, []*git.Commit) {
+	sections := map[string][]Entry{}
+	var breaking []Entry
+	var skipped []*git.Commit
+
+	defaults := map[string]string{}
+	var precedence []string
+	for _, s := range opts.Preset.Release.Sections {
+		defaults[s.Type] = s.Title
+		precedence = append(precedence, s.Type)
+	}
+
+	// Without a hidden list, only types with a section are shown
+	hidden := func(t string) bool {
+		_, configured := opts.Config.Sections[t]
+		return defaults[t] == "" && !configured
+	}
+	if opts.Config.Hidden != nil {
+		listed := map[string]bool{}
+		for _, t := range opts.Config.Hidden {
+			listed[t] = true
+		}
+		hidden = func(t string) bool { return listed[t] }
+	}
+
+	for _, c := range commits {
+		parsed, ok := opts.Preset.Parse(c.FullMessage())
+		if !ok || parsed.Type == "" {
+			skipped = append(skipped, c)
+			continue
+		}
+
+		entry := Entry{Commit: c, Parsed: parsed}
+		if parsed.IsBreaking {
+			breaking = append(breaking, entry)
+		}
+
+		if hidden(parsed.Type) {
+			continue
+		}
+		sections[parsed.Type] = append(sections[parsed.Type], entry)
+	}
+
+	var b strings.Builder
+
+	heading := opts.Version
+	if heading == "" {
+		heading = "Unreleased"
+	}
+	if opts.Date != "" {
+		heading += " (" + opts.Date + ")"
+	}
+	fmt.Fprintf(&b, "## %s\n\n", heading)
+
+	if len(breaking) > 0 {
+		writeSection(&b, breakingTitle, breaking, opts, true)
+	}
+
+	for _, t := range sectionOrder(sections, precedence, opts.Config.Sections) {
+		title := opts.Config.Sections[t]
+		if title == "" {
+			title = defaults[t]
+		}
+		if title == "" {
+			title = t
+		}
+		writeSection(&b, title, sections[t], opts, false)
+	}
+
+	return b.String(), skipped
+}
+
+// sectionOrder returns the types present in sections: the preset's
+// sections in precedence order, then configured types, then the rest
+// alphabetically
+func sectionOrder(sections map[string][]Entry, precedence []string, titles map[string]string) []string {
+	var order []string
+	seen := map[string]bool{}
+	for _, t := range precedence {
+		if len(sections[t]) > 0 {
+			order = append(order, t)
+			seen[t] = true
+		}
+	}
+
+	var configured, rest []string
+	for t := range sections {
+		if seen[t] {
+			continue
+		}
+		if _, ok := titles[t]; ok {
+			configured = append(configured, t)
+		} else {
+			rest = append(rest, t)
+		}
+	}
+	sort.Strings(configured)
+	sort.Strings(rest)
+
+	return append(append(order, configured...), rest...)
+}
+
+// writeSection writes one section, grouping entries by scope
+func writeSection(b *strings.Builder, title string, entries []Entry, opts Options, breaking bool) {
+	fmt.Fprintf(b, "### %s\n\n", title)
+
+	// Unscoped entries first, then scopes alphabetically
+	byScope := map[string][]Entry{}
+	var scopes []string
+	for _, e := range entries {
+		if _, ok := byScope[e.Parsed.Scope]; !ok {
+			scopes = append(scopes, e.Parsed.Scope)
+		}
+		byScope[e.Parsed.Scope] = append(byScope[e.Parsed.Scope], e)
+	}
+	sort.Strings(scopes)
+
+	for _, scope := range scopes {
+		for _, e := range byScope[scope] {
+			text := e.Parsed.Description
+			if breaking && e.Parsed.BreakingNote != "" {
+				text = e.Parsed.BreakingNote
+			}
+
+			line := "- "
+			if scope != "" {
+				line += "**" + scope + ":** "
+			}
+			line += linkIssues(text, opts.RepoURL)
+			line += " (" + linkHash(e.Commit, opts.RepoURL) + ")"
+			b.WriteString(line + "\n")
+		}
+	}
+	b.WriteString("\n")
+}
+
+// linkHash renders a commit hash, linked when the repository URL is known
+func linkHash(c *git.Commit, repoURL string) string {
+	short := c.ShortHash
+	if short == "" && len(c.Hash) >= 7 {
+		short = c.Hash[:7]
+	}
+	if repoURL == "" {
+		return short
+	}
+	return fmt.Sprintf("[%s](%s/commit/%s)", short, repoURL, c.Hash)
+}
+
+// linkIssues turns #123 references into links to the issue tracker
+func linkIssues(text, repoURL string) string {
+	if repoURL == "" {
+		return text
+	}
+	return issueRefRe.ReplaceAllString(text, fmt.Sprintf("$1[#$2](%s/issues/$2)", repoURL))
+}
+
+// Prepend inserts a new release section into an existing changelog, after
+// a leading "# Changelog" title if one is present
+func Prepend(existing, section string) string {
+	if existing == "" {
+		return "# Changelog\n\n" + section
+	}
+
+	if strings.HasPrefix(existing, "# ") {
+		idx := strings.Index(existing, "\n")
+		if idx < 0 {
+			return existing + "\n\n" + section
+		}
+		title := existing[:idx+1]
+		rest := strings.TrimLeft(existing[idx+1:], "\n")
+		return title + "\n" + section + rest
+	}
+
+	return section + existing
+}
@@ -0,0 +1,200 @@
+package ci
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+)
+
+// zeroSHA is what CI systems report as the previous commit of a new branch
+const zeroSHA = "0000000000000000000000000000000000000000"
+
+// Detection describes the commit range a CI run should lint
+type Detection struct {
+	// Provider is the human-readable CI system name
+	Provider string
+	// Base is either a branch name (lint commits since the merge base) or a
+	// commit SHA (lint commits after it)
+	Base string
+	// BaseIsBranch reports whether Base names a branch
+	BaseIsBranch bool
+	// Head is the last commit to lint
+	Head string
+	// Reason explains which variables were used
+	Reason string
+}
+
+// Getenv looks up an environment variable; os.Getenv satisfies it
+type Getenv func(string) string
+
+// provider inspects the environment for one CI system. It returns nil if
+// the system is not detected, and a Detection with an empty Base if the
+// system is detected but no range could be determined.
+type provider func(env Getenv) *Detection
+
+var providers = []provider{
+	githubActions,
+	gitlabCI,
+	jenkins,
+	buildkite,
+	circleCI,
+	azurePipelines,
+}
+
+// Detect determines the CI provider and commit range from the environment.
+// It returns nil when not running in a recognized CI system.
+func Detect(env Getenv) *Detection {
+	for _, p := range providers {
+		if d := p(env); d != nil {
+			if d.Head == "" {
+				d.Head = "HEAD"
+			}
+			return d
+		}
+	}
+	return nil
+}
+
+func githubActions(env Getenv) *Detection {
+	if env("GITHUB_ACTIONS") != "true" {
+		return nil
+	}
+	d := &Detection{Provider: "GitHub Actions"}
+
+	// The event payload carries exact SHAs for both pull requests and pushes
+	if path := env("GITHUB_EVENT_PATH"); path != "" {
+		if event, err := readGitHubEvent(path); err == nil {
+			switch {
+			case event.PullRequest.Base.SHA != "":
+				d.Base = event.PullRequest.Base.SHA
+				d.Head = event.PullRequest.Head.SHA
+				d.Reason = "pull_request.base.sha from GITHUB_EVENT_PATH"
+				return d
+			case event.Before != "" && event.Before != zeroSHA:
+				d.Base = event.Before
+				d.Head = event.After
+				d.Reason = "push before/after from GITHUB_EVENT_PATH"
+				return d
+			}
+		}
+	}
+
+	if base := env("GITHUB_BASE_REF"); base != "" {
+		d.Base = base
+		d.BaseIsBranch = true
+		d.Reason = "GITHUB_BASE_REF"
+	}
+	return d
+}
+
+// gitHubEvent holds the parts of a GitHub webhook payload we need
+type gitHubEvent struct {
+	Before      string `json:"before"`
+	After       string `json:"after"`
+	PullRequest struct {
+		Base struct {
+			SHA string `json:"sha"`
+		} `json:"base"`
+		Head struct {
+			SHA string `json:"sha"`
+		} `json:"head"`
+	} `json:"pull_request"`
+}
+
+func readGitHubEvent(path string) (*gitHubEvent, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var event gitHubEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+func gitlabCI(env Getenv) *Detection {
+	if env("GITLAB_CI") != "true" {
+		return nil
+	}
+	d := &Detection{Provider: "GitLab CI", Head: env("CI_COMMIT_SHA")}
+
+	switch {
+	case env("CI_MERGE_REQUEST_DIFF_BASE_SHA") != "":
+		d.Base = env("CI_MERGE_REQUEST_DIFF_BASE_SHA")
+		d.Reason = "CI_MERGE_REQUEST_DIFF_BASE_SHA"
+	case env("CI_MERGE_REQUEST_TARGET_BRANCH_NAME") != "":
+		d.Base = env("CI_MERGE_REQUEST_TARGET_BRANCH_NAME")
+		d.BaseIsBranch = true
+		d.Reason = "CI_MERGE_REQUEST_TARGET_BRANCH_NAME"
+	case env("CI_COMMIT_BEFORE_SHA") != "" && env("CI_COMMIT_BEFORE_SHA") != zeroSHA:
+		d.Base = env("CI_COMMIT_BEFORE_SHA")
+		d.Reason = "CI_COMMIT_BEFORE_SHA"
+	}
+	return d
+}
+
+func jenkins(env Getenv) *Detection {
+	if env("JENKINS_URL") == "" {
+		return nil
+	}
+	d := &Detection{Provider: "Jenkins", Head: env("GIT_COMMIT")}
+
+	switch {
+	case env("CHANGE_TARGET") != "":
+		d.Base = env("CHANGE_TARGET")
+		d.BaseIsBranch = true
+		d.Reason = "CHANGE_TARGET"
+	case env("GIT_PREVIOUS_SUCCESSFUL_COMMIT") != "":
+		d.Base = env("GIT_PREVIOUS_SUCCESSFUL_COMMIT")
+		d.Reason = "GIT_PREVIOUS_SUCCESSFUL_COMMIT"
+	}
+	return d
+}
+
+func buildkite(env Getenv) *Detection {
+	if env("BUILDKITE") != "true" {
+		return nil
+	}
+	d := &Detection{Provider: "Buildkite", Head: env("BUILDKITE_COMMIT")}
+
+	if pr := env("BUILDKITE_PULL_REQUEST"); pr != "" && pr != "false" {
+		if base := env("BUILDKITE_PULL_REQUEST_BASE_BRANCH"); base != "" {
+			d.Base = base
+			d.BaseIsBranch = true
+			d.Reason = "BUILDKITE_PULL_REQUEST_BASE_BRANCH"
+		}
+	}
+	return d
+}
+
+func circleCI(env Getenv) *Detection {
+	if env("CIRCLECI") != "true" {
+		return nil
+	}
+	d := &Detection{Provider: "CircleCI", Head: env("CIRCLE_SHA1")}
+
+	// CircleCI does not expose the pull request base branch, so compare
+	// against the remote's default branch unless we are building it
+	if env("CIRCLE_PULL_REQUEST") != "" {
+		d.Base = "origin/HEAD"
+		d.BaseIsBranch = true
+		d.Reason = "CIRCLE_PULL_REQUEST (base is the default branch, origin/HEAD)"
+	}
+	return d
+}
+
+func azurePipelines(env Getenv) *Detection {
+	if !strings.EqualFold(env("TF_BUILD"), "true") {
+		return nil
+	}
+	d := &Detection{Provider: "Azure Pipelines", Head: env("BUILD_SOURCEVERSION")}
+
+	if target := env("SYSTEM_PULLREQUEST_TARGETBRANCH"); target != "" {
+		d.Base = strings.TrimPrefix(target, "refs/heads/")
+		d.BaseIsBranch = true
+		d.Reason = "SYSTEM_PULLREQUEST_TARGETBRANCH"
+	}
+	return d
+}
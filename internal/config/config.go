@@ -0,0 +1,139 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"commit-linter/internal/linter"
+)
+
+// FileName is the name of the configuration file looked up in the
+// repository root
+const FileName = ".commitlint.json"
+
+// Config holds the team configuration for commit-lint
+type Config struct {
+	// Preset names the commit convention: conventional, angular, gitmoji,
+	// jira or kernel
+	Preset     string           `json:"preset"`
+	Changelog  ChangelogConfig  `json:"changelog"`
+	Hooks      HooksConfig      `json:"hooks"`
+	Ignore     IgnoreConfig     `json:"ignore"`
+	Directives DirectivesConfig `json:"directives"`
+	Scoring    ScoringConfig    `json:"scoring"`
+}
+
+// ChangelogConfig controls how changelogs are generated
+type ChangelogConfig struct {
+	// Sections maps a commit type to its section title, adding to and
+	// overriding the preset's sections
+	Sections map[string]string `json:"sections"`
+	// Hidden lists commit types that are left out of the changelog. When
+	// unset, types without a section are left out.
+	Hidden []string `json:"hidden"`
+}
+
+// HooksConfig controls the generated Git hooks
+type HooksConfig struct {
+	// MissingBinary is "fail" or "warn": what the hook does when it cannot
+	// find the commit-lint binary
+	MissingBinary string `json:"missingBinary"`
+}
+
+// IgnoreConfig selects commits that are skipped instead of validated
+type IgnoreConfig struct {
+	// Merges skips commits with more than one parent and Git's default
+	// merge messages
+	Merges bool `json:"merges"`
+	// Reverts skips messages generated by git revert
+	Reverts bool `json:"reverts"`
+	// Autosquash skips fixup!, squash! and amend! commits
+	Autosquash bool `json:"autosquash"`
+	// Authors are regular expressions matched against the author email
+	Authors []string `json:"authors"`
+	// Patterns are regular expressions matched against the full message
+	Patterns []string `json:"patterns"`
+}
+
+// DirectivesConfig controls Lint-Disable trailers in commit messages
+type DirectivesConfig struct {
+	// Enabled allows Lint-Disable trailers at all
+	Enabled bool `json:"enabled"`
+	// Forbidden lists rules that cannot be disabled
+	Forbidden []string `json:"forbidden"`
+}
+
+// ScoringConfig controls how violations translate into a 0-100 score
+type ScoringConfig struct {
+	// Weights sets the points deducted per rule, overriding the level
+	// defaults below
+	Weights map[string]int `json:"weights"`
+	// ErrorWeight is deducted for each error-level violation
+	ErrorWeight int `json:"errorWeight"`
+	// WarningWeight is deducted for each warning-level violation
+	WarningWeight int `json:"warningWeight"`
+	// MinScore fails validation below this score, even without errors
+	MinScore int `json:"minScore"`
+}
+
+// Default returns the built-in configuration
+func Default() *Config {
+	return &Config{
+		Preset: "conventional",
+		Changelog: ChangelogConfig{
+			Sections: map[string]string{},
+		},
+		Hooks: HooksConfig{
+			MissingBinary: "fail",
+		},
+		Ignore: IgnoreConfig{
+			Merges:     true,
+			Reverts:    true,
+			Autosquash: true,
+			Authors: []string{
+				`\[bot\]@users\.noreply\.github\.com$`,
+				`^bot@renovateapp\.com$`,
+			},
+		},
+		Scoring: ScoringConfig{
+			Weights:       map[string]int{},
+			ErrorWeight:   100,
+			WarningWeight: 10,
+		},
+		Directives: DirectivesConfig{
+			Enabled:   true,
+			Forbidden: append([]string(nil), linter.DefaultForbiddenDirectives...),
+		},
+	}
+}
+
+// Load reads the configuration file from dir. A missing file is not an
+// error; the defaults are returned instead. Values present in the file
+// override the defaults.
+func Load(dir string) (*Config, error) {
+	path := filepath.Join(dir, FileName)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return Default(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %v", err)
+	}
+
+	return Parse(data, path)
+}
+
+// Parse reads configuration from data, overriding the defaults. source
+// names where it came from in error messages.
+func Parse(data []byte, source string) (*Config, error) {
+	cfg := Default()
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", source, err)
+	}
+	if _, err := linter.LookupPreset(cfg.Preset); err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", source, err)
+	}
+	return cfg, nil
+}
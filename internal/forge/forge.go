@@ -0,0 +1,206 @@
+package forge
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Supported forges
+const (
+	GitHub = "github"
+	GitLab = "gitlab"
+	Gitea  = "gitea"
+)
+
+// State is the outcome shown by a commit status
+type State string
+
+// Commit status states; clients map them to their forge's names
+const (
+	StatePending State = "pending"
+	StateSuccess State = "success"
+	StateFailure State = "failure"
+)
+
+// Status is a commit status, shown as a check next to the commit
+type Status struct {
+	State State
+	// Context identifies the check, so later statuses replace it
+	Context     string
+	Description string
+	TargetURL   string
+}
+
+// Client talks to a forge's REST API
+type Client interface {
+	// SetStatus sets a commit status on sha
+	SetStatus(sha string, status Status) error
+	// UpsertComment updates the pull request comment containing marker, or
+	// adds one if there is none, so reruns don't pile up comments
+	UpsertComment(pr int, marker, body string) error
+}
+
+// Options configures a Client
+type Options struct {
+	// Forge is github, gitlab or gitea
+	Forge string
+	// BaseURL is the API root, e.g. https://api.github.com; empty uses the
+	// public default where there is one
+	BaseURL string
+	// Repository is owner/name (GitLab: the project path)
+	Repository string
+	Token      string
+	// HTTPClient defaults to one with a 30 second timeout
+	HTTPClient *http.Client
+}
+
+// New returns the client for opts.Forge
+func New(opts Options) (Client, error) {
+	if opts.Repository == "" {
+		return nil, fmt.Errorf("no repository given for %s", opts.Forge)
+	}
+	if opts.Token == "" {
+		return nil, fmt.Errorf("no %s token found; set %s", opts.Forge, TokenVariable(opts.Forge))
+	}
+
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	a := &api{base: strings.TrimSuffix(opts.BaseURL, "/"), header: http.Header{}, client: httpClient}
+
+	switch opts.Forge {
+	case GitHub:
+		if a.base == "" {
+			a.base = "https://api.github.com"
+		}
+		a.header.Set("Authorization", "Bearer "+opts.Token)
+		a.header.Set("Accept", "application/vnd.github+json")
+		return &gitHub{api: a, repo: opts.Repository}, nil
+	case GitLab:
+		if a.base == "" {
+			a.base = "https://gitlab.com/api/v4"
+		}
+		a.header.Set("PRIVATE-TOKEN", opts.Token)
+		return &gitLab{api: a, project: opts.Repository}, nil
+	case Gitea:
+		if a.base == "" {
+			return nil, fmt.Errorf("gitea needs an API base URL, e.g. https://gitea.example.com/api/v1")
+		}
+		a.header.Set("Authorization", "token "+opts.Token)
+		return &gitea{api: a, repo: opts.Repository}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported forge %q (use github, gitlab or gitea)", opts.Forge)
+}
+
+// TokenVariable names the environment variable holding a forge's token
+func TokenVariable(forge string) string {
+	return strings.ToUpper(forge) + "_TOKEN"
+}
+
+// api performs JSON requests against a REST API root
+type api struct {
+	base   string
+	header http.Header
+	client *http.Client
+}
+
+// do sends in as the JSON body (if not nil) and decodes the response into
+// out (if not nil)
+func (a *api) do(method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, a.base+path, body)
+	if err != nil {
+		return err
+	}
+	for name, values := range a.header {
+		req.Header[name] = values
+	}
+	req.Header.Set("User-Agent", "commit-lint")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s: %s: %s", method, path, resp.Status, strings.TrimSpace(string(data)))
+	}
+
+	if out != nil {
+		if err := json.Unmarshal(data, out); err != nil {
+			return fmt.Errorf("%s %s: malformed response: %v", method, path, err)
+		}
+	}
+	return nil
+}
+
+// withoutEmpty drops empty fields, which some APIs reject instead of
+// treating as unset
+func withoutEmpty(fields map[string]string) map[string]string {
+	for name, value := range fields {
+		if value == "" {
+			delete(fields, name)
+		}
+	}
+	return fields
+}
+
+// comment is the subset of a PR comment shared by all forges
+type comment struct {
+	ID   int64  `json:"id"`
+	Body string `json:"body"`
+}
+
+// perPage is the page size requested when listing comments
+const perPage = 100
+
+// findComment pages through comments at path and returns the ID of the
+// first one containing marker, or 0. Servers cap page sizes differently
+// (and some ignore paging), so it stops at the first page with nothing new.
+func (a *api) findComment(path, marker string) (int64, error) {
+	seen := map[int64]bool{}
+	for page := 1; ; page++ {
+		var comments []comment
+		err := a.do(http.MethodGet, fmt.Sprintf("%s?per_page=%d&limit=%d&page=%d", path, perPage, perPage, page), nil, &comments)
+		if err != nil {
+			return 0, err
+		}
+
+		fresh := false
+		for _, c := range comments {
+			if strings.Contains(c.Body, marker) {
+				return c.ID, nil
+			}
+			if !seen[c.ID] {
+				seen[c.ID] = true
+				fresh = true
+			}
+		}
+		if !fresh {
+			return 0, nil
+		}
+	}
+}
@@ -0,0 +1,290 @@
+package forge
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// request is one call received by the fake server
+type request struct {
+	Method string
+	Path   string
+	Body   map[string]string
+	Header http.Header
+}
+
+// fakeForge serves the comment listing of one pull request and records
+// every request. pageSize caps pages like real servers do; ignorePaging
+// makes it return the first page for every page number.
+type fakeForge struct {
+	commentsPath string
+	comments     []comment
+	pageSize     int
+	ignorePaging bool
+
+	mu       sync.Mutex
+	requests []request
+}
+
+func (f *fakeForge) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	req := request{Method: r.Method, Path: r.URL.EscapedPath(), Header: r.Header}
+	if r.Body != nil {
+		json.NewDecoder(r.Body).Decode(&req.Body)
+	}
+	f.mu.Lock()
+	f.requests = append(f.requests, req)
+	f.mu.Unlock()
+
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{}`))
+		return
+	}
+	if req.Path != f.commentsPath {
+		http.NotFound(w, r)
+		return
+	}
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 || f.ignorePaging {
+		page = 1
+	}
+	start := min((page-1)*f.pageSize, len(f.comments))
+	end := min(start+f.pageSize, len(f.comments))
+	json.NewEncoder(w).Encode(f.comments[start:end])
+}
+
+// calls returns the requests with the given method
+func (f *fakeForge) calls(method string) []request {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var matched []request
+	for _, r := range f.requests {
+		if r.Method == method {
+			matched = append(matched, r)
+		}
+	}
+	return matched
+}
+
+// forgeCase describes the API layout of one forge
+type forgeCase struct {
+	forge        string
+	repo         string
+	statusPath   string
+	commentsPath string
+	// updateMethod and updatePath edit comment 42
+	updateMethod string
+	updatePath   string
+	failureState string
+	// nameField is the status field carrying the context
+	nameField  string
+	authHeader string
+	authValue  string
+}
+
+var forgeCases = []forgeCase{
+	{
+		forge:        GitHub,
+		repo:         "octo-org/payments",
+		statusPath:   "/repos/octo-org/payments/statuses/abc123",
+		commentsPath: "/repos/octo-org/payments/issues/7/comments",
+		updateMethod: http.MethodPatch,
+		updatePath:   "/repos/octo-org/payments/issues/comments/42",
+		failureState: "failure",
+		nameField:    "context",
+		authHeader:   "Authorization",
+		authValue:    "Bearer secret-token",
+	},
+	{
+		forge:        GitLab,
+		repo:         "platform/billing",
+		statusPath:   "/projects/platform%2Fbilling/statuses/abc123",
+		commentsPath: "/projects/platform%2Fbilling/merge_requests/7/notes",
+		updateMethod: http.MethodPut,
+		updatePath:   "/projects/platform%2Fbilling/merge_requests/7/notes/42",
+		failureState: "failed",
+		nameField:    "name",
+		authHeader:   "Private-Token",
+		authValue:    "secret-token",
+	},
+	{
+		forge:        Gitea,
+		repo:         "infra/terraform",
+		statusPath:   "/repos/infra/terraform/statuses/abc123",
+		commentsPath: "/repos/infra/terraform/issues/7/comments",
+		updateMethod: http.MethodPatch,
+		updatePath:   "/repos/infra/terraform/issues/comments/42",
+		failureState: "failure",
+		nameField:    "context",
+		authHeader:   "Authorization",
+		authValue:    "token secret-token",
+	},
+}
+
+func newTestClient(t *testing.T, tc forgeCase, fake *fakeForge) Client {
+	t.Helper()
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	client, err := New(Options{Forge: tc.forge, BaseURL: server.URL, Repository: tc.repo, Token: "secret-token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+// otherComments returns n comments without the marker
+func otherComments(n int) []comment {
+	comments := make([]comment, n)
+	for i := range comments {
+		comments[i] = comment{ID: int64(i + 1), Body: fmt.Sprintf("LGTM %d", i+1)}
+	}
+	return comments
+}
+
+func TestSetStatus(t *testing.T) {
+	for _, tc := range forgeCases {
+		t.Run(tc.forge, func(t *testing.T) {
+			fake := &fakeForge{commentsPath: tc.commentsPath, pageSize: perPage}
+			client := newTestClient(t, tc, fake)
+
+			for _, state := range []State{StateSuccess, StateFailure} {
+				err := client.SetStatus("abc123", Status{State: state, Context: StatusContext, Description: "1 of 3 invalid"})
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			posts := fake.calls(http.MethodPost)
+			if len(posts) != 2 {
+				t.Fatalf("%d status requests, want 2", len(posts))
+			}
+			for _, post := range posts {
+				if post.Path != tc.statusPath {
+					t.Errorf("path = %s, want %s", post.Path, tc.statusPath)
+				}
+				if post.Body[tc.nameField] != StatusContext {
+					t.Errorf("%s = %q, want %q", tc.nameField, post.Body[tc.nameField], StatusContext)
+				}
+				if _, ok := post.Body["target_url"]; ok {
+					t.Error("empty target_url was sent")
+				}
+				if got := post.Header.Get(tc.authHeader); got != tc.authValue {
+					t.Errorf("%s = %q, want %q", tc.authHeader, got, tc.authValue)
+				}
+			}
+			if got := posts[0].Body["state"]; got != "success" {
+				t.Errorf("success state = %q", got)
+			}
+			if got := posts[1].Body["state"]; got != tc.failureState {
+				t.Errorf("failure state = %q, want %q", got, tc.failureState)
+			}
+		})
+	}
+}
+
+func TestUpsertCommentCreates(t *testing.T) {
+	for _, tc := range forgeCases {
+		t.Run(tc.forge, func(t *testing.T) {
+			fake := &fakeForge{commentsPath: tc.commentsPath, comments: otherComments(3), pageSize: perPage}
+			client := newTestClient(t, tc, fake)
+
+			if err := client.UpsertComment(7, Marker, Marker+"\nreport"); err != nil {
+				t.Fatal(err)
+			}
+
+			posts := fake.calls(http.MethodPost)
+			if len(posts) != 1 || posts[0].Path != tc.commentsPath {
+				t.Fatalf("creates = %+v, want one POST to %s", posts, tc.commentsPath)
+			}
+			if posts[0].Body["body"] != Marker+"\nreport" {
+				t.Errorf("body = %q", posts[0].Body["body"])
+			}
+			if updates := fake.calls(tc.updateMethod); len(updates) != 0 {
+				t.Errorf("unexpected updates: %+v", updates)
+			}
+		})
+	}
+}
+
+func TestUpsertCommentUpdatesOnLaterPage(t *testing.T) {
+	for _, tc := range forgeCases {
+		t.Run(tc.forge, func(t *testing.T) {
+			// The server caps pages at 2, so the marker is on page 3
+			comments := append(otherComments(5), comment{ID: 42, Body: "old\n" + Marker})
+			fake := &fakeForge{commentsPath: tc.commentsPath, comments: comments, pageSize: 2}
+			client := newTestClient(t, tc, fake)
+
+			if err := client.UpsertComment(7, Marker, Marker+"\nnew"); err != nil {
+				t.Fatal(err)
+			}
+
+			if gets := fake.calls(http.MethodGet); len(gets) != 3 {
+				t.Errorf("%d pages fetched, want 3", len(gets))
+			}
+			updates := fake.calls(tc.updateMethod)
+			if len(updates) != 1 || updates[0].Path != tc.updatePath {
+				t.Fatalf("updates = %+v, want one %s to %s", updates, tc.updateMethod, tc.updatePath)
+			}
+			if updates[0].Body["body"] != Marker+"\nnew" {
+				t.Errorf("body = %q", updates[0].Body["body"])
+			}
+			if posts := fake.calls(http.MethodPost); len(posts) != 0 {
+				t.Errorf("unexpected creates: %+v", posts)
+			}
+		})
+	}
+}
+
+func TestFindCommentStops(t *testing.T) {
+	tests := []struct {
+		name  string
+		fake  *fakeForge
+		pages int
+	}{
+		{"empty page", &fakeForge{comments: otherComments(5), pageSize: 2}, 4},
+		{"paging ignored", &fakeForge{comments: otherComments(5), pageSize: 5, ignorePaging: true}, 2},
+		{"no comments", &fakeForge{pageSize: 2}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fake.commentsPath = "/comments"
+			server := httptest.NewServer(tt.fake)
+			defer server.Close()
+
+			a := &api{base: server.URL, header: http.Header{}, client: server.Client()}
+			id, err := a.findComment("/comments", Marker)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if id != 0 {
+				t.Errorf("id = %d, want 0", id)
+			}
+			if gets := tt.fake.calls(http.MethodGet); len(gets) != tt.pages {
+				t.Errorf("%d pages fetched, want %d", len(gets), tt.pages)
+			}
+		})
+	}
+}
+
+func TestAPIErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"Bad credentials"}`, http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client, err := New(Options{Forge: GitHub, BaseURL: server.URL, Repository: "octo-org/payments", Token: "expired"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.SetStatus("abc123", Status{State: StateSuccess, Context: StatusContext}); err == nil {
+		t.Error("SetStatus succeeded against a 401")
+	}
+}
@@ -0,0 +1,34 @@
+package forge
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// gitea uses the Gitea (and Forgejo) REST API v1
+type gitea struct {
+	api  *api
+	repo string
+}
+
+func (g *gitea) SetStatus(sha string, status Status) error {
+	return g.api.do(http.MethodPost, fmt.Sprintf("/repos/%s/statuses/%s", g.repo, sha), withoutEmpty(map[string]string{
+		"state":       string(status.State),
+		"context":     status.Context,
+		"description": status.Description,
+		"target_url":  status.TargetURL,
+	}), nil)
+}
+
+func (g *gitea) UpsertComment(pr int, marker, body string) error {
+	id, err := g.api.findComment(fmt.Sprintf("/repos/%s/issues/%d/comments", g.repo, pr), marker)
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]string{"body": body}
+	if id != 0 {
+		return g.api.do(http.MethodPatch, fmt.Sprintf("/repos/%s/issues/comments/%d", g.repo, id), payload, nil)
+	}
+	return g.api.do(http.MethodPost, fmt.Sprintf("/repos/%s/issues/%d/comments", g.repo, pr), payload, nil)
+}
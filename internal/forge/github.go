@@ -0,0 +1,44 @@
+package forge
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// gitHub uses the GitHub REST API (also GitHub Enterprise via BaseURL)
+type gitHub struct {
+	api  *api
+	repo string
+}
+
+func (g *gitHub) SetStatus(sha string, status Status) error {
+	return g.api.do(http.MethodPost, fmt.Sprintf("/repos/%s/statuses/%s", g.repo, sha), withoutEmpty(map[string]string{
+		"state":       string(status.State),
+		"context":     status.Context,
+		"description": truncate(status.Description, 140),
+		"target_url":  status.TargetURL,
+	}), nil)
+}
+
+func (g *gitHub) UpsertComment(pr int, marker, body string) error {
+	// Pull request conversation comments are issue comments
+	id, err := g.api.findComment(fmt.Sprintf("/repos/%s/issues/%d/comments", g.repo, pr), marker)
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]string{"body": body}
+	if id != 0 {
+		return g.api.do(http.MethodPatch, fmt.Sprintf("/repos/%s/issues/comments/%d", g.repo, id), payload, nil)
+	}
+	return g.api.do(http.MethodPost, fmt.Sprintf("/repos/%s/issues/%d/comments", g.repo, pr), payload, nil)
+}
+
+// truncate shortens s to n characters, as status descriptions are limited
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
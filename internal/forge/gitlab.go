@@ -0,0 +1,47 @@
+package forge
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// gitLab uses the GitLab REST API v4
+type gitLab struct {
+	api     *api
+	project string
+}
+
+// projectPath is the URL-encoded project, which GitLab accepts in place of
+// the numeric ID
+func (g *gitLab) projectPath() string {
+	return "/projects/" + url.PathEscape(g.project)
+}
+
+func (g *gitLab) SetStatus(sha string, status Status) error {
+	state := string(status.State)
+	if status.State == StateFailure {
+		state = "failed"
+	}
+
+	return g.api.do(http.MethodPost, fmt.Sprintf("%s/statuses/%s", g.projectPath(), sha), withoutEmpty(map[string]string{
+		"state":       state,
+		"name":        status.Context,
+		"description": truncate(status.Description, 255),
+		"target_url":  status.TargetURL,
+	}), nil)
+}
+
+func (g *gitLab) UpsertComment(mr int, marker, body string) error {
+	notes := fmt.Sprintf("%s/merge_requests/%d/notes", g.projectPath(), mr)
+	id, err := g.api.findComment(notes, marker)
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]string{"body": body}
+	if id != 0 {
+		return g.api.do(http.MethodPut, fmt.Sprintf("%s/%d", notes, id), payload, nil)
+	}
+	return g.api.do(http.MethodPost, notes, payload, nil)
+}
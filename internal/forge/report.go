@@ -0,0 +1,125 @@
+package forge
+
+import (
+	"fmt"
+	"strings"
+
+	"commit-linter/internal/linter"
+	"commit-linter/internal/stats"
+)
+
+// Marker identifies the commit-lint comment so reruns update it
+const Marker = "<!-- commit-lint -->"
+
+// StatusContext names the commit status
+const StatusContext = "commit-lint"
+
+// maxRows bounds the commit table in the comment
+const maxRows = 50
+
+// Report sets a red or green status on the target commit and, for pull
+// requests, upserts a summary comment. preset is the convention the
+// commits were linted against.
+func Report(client Client, target Target, entries []stats.Entry, preset linter.Preset) error {
+	status, comment := Summarize(entries, preset)
+	status.TargetURL = target.TargetURL
+
+	if target.SHA != "" {
+		if err := client.SetStatus(target.SHA, status); err != nil {
+			return fmt.Errorf("failed to set commit status: %v", err)
+		}
+	}
+
+	if target.PullRequest > 0 {
+		if err := client.UpsertComment(target.PullRequest, Marker, comment); err != nil {
+			return fmt.Errorf("failed to comment on #%d: %v", target.PullRequest, err)
+		}
+	}
+
+	return nil
+}
+
+// Summarize renders the status and the Markdown comment for the checked
+// commits
+func Summarize(entries []stats.Entry, preset linter.Preset) (Status, string) {
+	linted, invalid := 0, 0
+	for _, e := range entries {
+		if e.Result == nil {
+			continue
+		}
+		linted++
+		if !e.Result.IsValid {
+			invalid++
+		}
+	}
+
+	status := Status{State: StateSuccess, Context: StatusContext}
+	var headline string
+	switch {
+	case invalid > 0:
+		status.State = StateFailure
+		status.Description = fmt.Sprintf("%d of %d commit message(s) need fixing", invalid, linted)
+		headline = "❌ commit-lint: " + status.Description
+	case linted == 0:
+		status.Description = "No commits to lint"
+		headline = "⏭️ commit-lint: no commits to lint"
+	default:
+		status.Description = fmt.Sprintf("All %d commit message(s) are valid", linted)
+		headline = "✅ commit-lint: " + strings.ToLower(status.Description[:1]) + status.Description[1:]
+	}
+
+	var b strings.Builder
+	b.WriteString(Marker + "\n")
+	b.WriteString("### " + headline + "\n\n")
+
+	if len(entries) > 0 {
+		b.WriteString("| Commit | Subject | Result |\n")
+		b.WriteString("|---|---|---|\n")
+		for i, e := range entries {
+			if i == maxRows {
+				fmt.Fprintf(&b, "\n…and %d more commit(s).\n", len(entries)-maxRows)
+				break
+			}
+			fmt.Fprintf(&b, "| `%s` | %s | %s |\n", e.Commit.ShortHash, escapeCell(e.Commit.Message), resultCell(e))
+		}
+	}
+
+	if invalid > 0 {
+		convention := preset.Title
+		if preset.URL != "" {
+			convention = "[" + preset.Title + "](" + preset.URL + ")"
+		}
+		fmt.Fprintf(&b, "\nReword the failing commits (e.g. `git rebase -i`) to follow %s: `%s`.\n",
+			convention, preset.Format)
+	}
+
+	return status, b.String()
+}
+
+func resultCell(e stats.Entry) string {
+	if e.Result == nil {
+		return "⏭️ ignored (" + escapeCell(e.Ignored) + ")"
+	}
+	if e.Result.IsValid {
+		return fmt.Sprintf("✅ %d/100", e.Result.Score)
+	}
+
+	// Name the errors; a commit failing only on min-score has none
+	var errors, all []string
+	for _, v := range e.Result.Violations {
+		all = append(all, "`"+v.Rule+"`")
+		if v.Level == "error" {
+			errors = append(errors, "`"+v.Rule+"`")
+		}
+	}
+	rules := errors
+	if len(rules) == 0 {
+		rules = all
+	}
+	return fmt.Sprintf("❌ %d/100 %s", e.Result.Score, strings.Join(rules, ", "))
+}
+
+// escapeCell keeps text from breaking out of a Markdown table cell
+func escapeCell(s string) string {
+	return strings.NewReplacer("|", "\\|", "\n", " ").Replace(s)
+}
@@ -0,0 +1,92 @@
+package forge
+
+import (
+	"encoding/json"
+	"os"
+	"strconv"
+
+	"commit-linter/internal/ci"
+)
+
+// Target is where lint results are reported
+type Target struct {
+	Forge      string
+	BaseURL    string
+	Repository string
+	// PullRequest is the pull or merge request number; 0 skips the comment
+	PullRequest int
+	// SHA is the commit that gets the status
+	SHA string
+	// TargetURL links the status to the CI run
+	TargetURL string
+}
+
+// DetectTarget fills in a Target from the environment of GitHub Actions,
+// Gitea Actions or GitLab CI. Fields it cannot determine are left empty.
+func DetectTarget(env ci.Getenv) Target {
+	switch {
+	case env("GITHUB_ACTIONS") == "true":
+		t := Target{
+			Forge:      GitHub,
+			BaseURL:    env("GITHUB_API_URL"),
+			Repository: env("GITHUB_REPOSITORY"),
+			SHA:        env("GITHUB_SHA"),
+		}
+		// Gitea Actions mimics GitHub Actions, API URL included
+		if env("GITEA_ACTIONS") == "true" {
+			t.Forge = Gitea
+		}
+		if server, run := env("GITHUB_SERVER_URL"), env("GITHUB_RUN_ID"); server != "" && run != "" {
+			t.TargetURL = server + "/" + t.Repository + "/actions/runs/" + run
+		}
+		if event, err := readPullRequestEvent(env("GITHUB_EVENT_PATH")); err == nil && event.Number > 0 {
+			t.PullRequest = event.Number
+			// GITHUB_SHA is the test merge commit; the status belongs on
+			// the pull request head
+			t.SHA = event.PullRequest.Head.SHA
+		}
+		return t
+	case env("GITLAB_CI") == "true":
+		iid, _ := strconv.Atoi(env("CI_MERGE_REQUEST_IID"))
+		return Target{
+			Forge:       GitLab,
+			BaseURL:     env("CI_API_V4_URL"),
+			Repository:  env("CI_PROJECT_PATH"),
+			PullRequest: iid,
+			SHA:         env("CI_COMMIT_SHA"),
+			TargetURL:   env("CI_JOB_URL"),
+		}
+	}
+	return Target{}
+}
+
+// Token returns the API token: $COMMIT_LINT_FORGE_TOKEN, or the forge's own
+// variable such as $GITHUB_TOKEN
+func Token(forge string, env ci.Getenv) string {
+	if token := env("COMMIT_LINT_FORGE_TOKEN"); token != "" {
+		return token
+	}
+	return env(TokenVariable(forge))
+}
+
+type pullRequestEvent struct {
+	Number      int `json:"number"`
+	PullRequest struct {
+		Head struct {
+			SHA string `json:"sha"`
+		} `json:"head"`
+	} `json:"pull_request"`
+}
+
+func readPullRequestEvent(path string) (*pullRequestEvent, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var event pullRequestEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
@@ -20,9 +20,7 @@ const (
 )
 
 // PrintValidationResult prints the validation result beautifully
-func PrintValidationResult(message string, result *linter.ValidationResult) {
-	commit := linter.ParseCommitMessage(message)
-
+func PrintValidationResult(preset linter.Preset, commit *linter.CommitMessage, result *linter.ValidationResult) {
 	fmt.Println()
 
 	if result.IsValid {
@@ -41,10 +39,15 @@ func PrintValidationResult(message string, result *linter.ValidationResult) {
 
 	// Parsed components
 	fmt.Println(Blue + Bold + "🔍 PARSED COMPONENTS:" + Reset)
-	if commit.Type != "" {
+	switch {
+	case commit.Type != "":
 		fmt.Printf("  Type:        %s%s%s\n", Green, commit.Type, Reset)
-	} else {
-		fmt.Printf("  Type:        %sMissing%s\n", Red, Reset)
+	case commit.Scope == "" && commit.Ticket == "":
+		fmt.Printf("  Header:      %sexpected %s%s\n", Red, preset.Format, Reset)
+	}
+
+	if commit.Ticket != "" {
+		fmt.Printf("  Ticket:      %s%s%s\n", Green, commit.Ticket, Reset)
 	}
 
 	if commit.Scope != "" {
@@ -75,6 +78,9 @@ func PrintValidationResult(message string, result *linter.ValidationResult) {
 
 	fmt.Printf("  Score:       %s%d/100%s\n",
 		getScoreColor(result.Score), result.Score, Reset)
+	if breakdown := scoreBreakdown(result); breakdown != "" {
+		fmt.Printf("  Breakdown:   %s%s%s\n", Gray, breakdown, Reset)
+	}
 
 	// Count violations by level
 	errors := 0
@@ -109,7 +115,23 @@ func PrintValidationResult(message string, result *linter.ValidationResult) {
 				icon = "⚠️"
 				color = Yellow
 			}
-			fmt.Printf("  %s %s%s%s\n", icon, color, v.Message, Reset)
+			penalty := ""
+			if v.Penalty > 0 {
+				penalty = fmt.Sprintf(" %s(-%d)%s", Gray, v.Penalty, Reset)
+			}
+			fmt.Printf("  %s %s%s%s%s\n", icon, color, v.Message, Reset, penalty)
+		}
+		fmt.Println()
+	}
+
+	// Show rules disabled by Lint-Disable directives so reviewers see them
+	if len(result.Disabled) > 0 {
+		fmt.Println(Gray + Bold + "🔕 DISABLED BY Lint-Disable:" + Reset)
+		for _, rule := range result.Disabled {
+			fmt.Printf("  • %s\n", rule)
+		}
+		for _, v := range result.Exempted {
+			fmt.Printf("    %s(exempted: %s)%s\n", Gray, v.Message, Reset)
 		}
 		fmt.Println()
 	}
@@ -126,17 +148,7 @@ func PrintValidationResult(message string, result *linter.ValidationResult) {
 	// Examples
 	if !result.IsValid {
 		fmt.Println(Blue + Bold + "📚 VALID EXAMPLES:" + Reset)
-		examples := []string{
-			"feat(auth): add login functionality",
-			"fix(api): resolve null pointer in user endpoint",
-			"docs(readme): update installation instructions",
-			"style(css): format button padding",
-			"refactor(auth): simplify token validation",
-			"test(login): add unit tests for authentication",
-			"chore(deps): update dependencies",
-		}
-
-		for _, ex := range examples {
+		for _, ex := range preset.Examples {
 			fmt.Printf("  • %s\n", ex)
 		}
 		fmt.Println()
@@ -152,6 +164,20 @@ func PrintValidationResult(message string, result *linter.ValidationResult) {
 	fmt.Println()
 }
 
+// scoreBreakdown explains the score as 100 minus each rule's penalty
+func scoreBreakdown(result *linter.ValidationResult) string {
+	parts := []string{"100"}
+	for _, v := range result.Violations {
+		if v.Penalty > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", v.Penalty, v.Rule))
+		}
+	}
+	if len(parts) == 1 {
+		return ""
+	}
+	return strings.Join(parts, " − ")
+}
+
 func getScoreColor(score int) string {
 	if score >= 80 {
 		return Green
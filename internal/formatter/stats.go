@@ -0,0 +1,117 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+
+	"commit-linter/internal/stats"
+)
+
+// PrintStats prints a commit-quality report
+func PrintStats(report *stats.Report) {
+	fmt.Println()
+	fmt.Println(Cyan + Bold + "📊 COMMIT QUALITY REPORT" + Reset)
+	fmt.Println(strings.Repeat("─", 50))
+	fmt.Println()
+
+	fmt.Println(Purple + Bold + "SUMMARY:" + Reset)
+	fmt.Printf("  Commits:         %d (%d ignored)\n", report.Commits, report.Ignored)
+	fmt.Printf("  Compliance:      %s%.0f%%%s (%d/%d valid)\n",
+		getScoreColor(int(report.Compliance)), report.Compliance, Reset, report.Valid, report.Linted)
+	fmt.Printf("  Average score:   %s%.0f/100%s\n",
+		getScoreColor(int(report.AvgScore)), report.AvgScore, Reset)
+	fmt.Printf("  Breaking:        %d\n", report.BreakingChanges)
+	fmt.Printf("  Subject length:  %.0f chars on average\n", report.AvgSubjectLength)
+	fmt.Println()
+
+	printGroups("BY TYPE:", report.Types)
+	printGroups("BY SCOPE:", report.Scopes)
+	printGroups("BY AUTHOR:", report.Authors)
+	printGroups("SCORE TREND:", report.Trend)
+
+	if len(report.Violations) > 0 {
+		fmt.Println(Yellow + Bold + "MOST VIOLATED RULES:" + Reset)
+		for _, v := range report.Violations {
+			color := Yellow
+			if v.Level == "error" {
+				color = Red
+			}
+			fmt.Printf("  %s%-28s%s %5d\n", color, v.Rule, Reset, v.Count)
+		}
+		fmt.Println()
+	}
+}
+
+// PrintScan prints a multi-repository report: the ranking, followed by
+// the rules most violated across all repositories
+func PrintScan(report *stats.ScanReport) {
+	fmt.Println()
+	fmt.Println(Cyan + Bold + "📊 MULTI-REPOSITORY COMMIT QUALITY" + Reset)
+	fmt.Println(strings.Repeat("─", 78))
+	fmt.Println()
+
+	summary := report.Summary
+	fmt.Println(Purple + Bold + "SUMMARY:" + Reset)
+	fmt.Printf("  Repositories:    %d (%d failed)\n", len(report.Repositories), report.Failed)
+	fmt.Printf("  Commits:         %d (%d ignored)\n", summary.Commits, summary.Ignored)
+	fmt.Printf("  Compliance:      %s%.0f%%%s (%d/%d valid)\n",
+		getScoreColor(int(summary.Compliance)), summary.Compliance, Reset, summary.Valid, summary.Linted)
+	fmt.Printf("  Average score:   %s%.0f/100%s\n",
+		getScoreColor(int(summary.AvgScore)), summary.AvgScore, Reset)
+	fmt.Println()
+
+	fmt.Println(Blue + Bold + "RANKING:" + Reset)
+	fmt.Printf("  %s%4s  %-32s %7s %10s %9s  %s%s\n", Gray, "#", "repository", "linted", "compliant", "avg score", "top rule", Reset)
+	for _, repo := range report.Repositories {
+		name := truncate(repo.Name, 32)
+		switch {
+		case repo.Error != "":
+			fmt.Printf("  %4s  %-32s %s❌ %s%s\n", "-", name, Red, repo.Error, Reset)
+		case repo.Rank == 0:
+			fmt.Printf("  %4s  %-32s %7d %s%10s%s\n", "-", name, repo.Commits, Gray, "none linted", Reset)
+		default:
+			fmt.Printf("  %4d  %-32s %7d %s%9.0f%%%s %9.0f  %s\n",
+				repo.Rank, name, repo.Linted,
+				getScoreColor(int(repo.Compliance)), repo.Compliance, Reset, repo.AvgScore, repo.TopRule)
+		}
+	}
+	fmt.Println()
+
+	if len(summary.Violations) > 0 {
+		fmt.Println(Yellow + Bold + "MOST VIOLATED RULES:" + Reset)
+		for _, v := range summary.Violations {
+			color := Yellow
+			if v.Level == "error" {
+				color = Red
+			}
+			fmt.Printf("  %s%-28s%s %5d\n", color, v.Rule, Reset, v.Count)
+		}
+		fmt.Println()
+	}
+}
+
+// printGroups prints one table of aggregated commits
+func printGroups(title string, groups []*stats.Group) {
+	if len(groups) == 0 {
+		return
+	}
+
+	fmt.Println(Blue + Bold + title + Reset)
+	fmt.Printf("  %s%-24s %7s %10s %9s%s\n", Gray, "", "commits", "compliant", "avg score", Reset)
+	for _, g := range groups {
+		fmt.Printf("  %-24s %7d %s%9.0f%%%s %9.0f\n",
+			truncate(g.Key, 24), g.Commits,
+			getScoreColor(int(g.Compliance)), g.Compliance, Reset, g.AvgScore)
+	}
+	fmt.Println()
+}
+
+// truncate shortens s to n characters, counting runes so multi-byte
+// names are never cut in half
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
@@ -0,0 +1,90 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// maxDeepenAttempts bounds how often a shallow clone is deepened while
+// searching for a merge base
+const maxDeepenAttempts = 5
+
+// deepenStep is the number of commits fetched per deepen attempt
+const deepenStep = 100
+
+// ResolveRef resolves a branch name to a commit reference. Local branches
+// are preferred; otherwise the remote-tracking branch on origin is used.
+func (r *Repository) ResolveRef(name string) (string, error) {
+	// Names such as release/1.0 often exist only on the remote in CI
+	candidates := []string{name}
+	if !strings.HasPrefix(name, "origin/") {
+		candidates = append(candidates, "origin/"+name)
+	}
+
+	for _, ref := range candidates {
+		cmd := exec.Command("git", "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+		cmd.Dir = r.Path
+		if err := cmd.Run(); err == nil {
+			return ref, nil
+		}
+	}
+
+	return "", fmt.Errorf("branch %q not found locally or on origin", name)
+}
+
+// IsShallow reports whether the repository is a shallow clone
+func (r *Repository) IsShallow() bool {
+	cmd := exec.Command("git", "rev-parse", "--is-shallow-repository")
+	cmd.Dir = r.Path
+
+	output, err := cmd.Output()
+	return err == nil && strings.TrimSpace(string(output)) == "true"
+}
+
+// IsDetachedHead reports whether HEAD does not point to a branch
+func (r *Repository) IsDetachedHead() bool {
+	cmd := exec.Command("git", "symbolic-ref", "--quiet", "HEAD")
+	cmd.Dir = r.Path
+	return cmd.Run() != nil
+}
+
+// MergeBase returns the best common ancestor of two references. In a
+// shallow clone the history is deepened until the merge base is found.
+func (r *Repository) MergeBase(a, b string) (string, error) {
+	for attempt := 0; ; attempt++ {
+		cmd := exec.Command("git", "merge-base", a, b)
+		cmd.Dir = r.Path
+
+		output, err := cmd.Output()
+		if err == nil {
+			return strings.TrimSpace(string(output)), nil
+		}
+
+		if !r.IsShallow() {
+			return "", fmt.Errorf("no common ancestor between %s and %s", a, b)
+		}
+
+		if attempt == maxDeepenAttempts {
+			return "", fmt.Errorf("no common ancestor between %s and %s in shallow clone "+
+				"(deepened %d times); run 'git fetch --unshallow' or use fetch-depth: 0 in CI",
+				a, b, maxDeepenAttempts)
+		}
+
+		if err := r.deepen(deepenStep); err != nil {
+			return "", fmt.Errorf("shallow clone is missing the merge base and deepening failed: %v; "+
+				"run 'git fetch --unshallow'", err)
+		}
+	}
+}
+
+// deepen fetches additional history for a shallow clone
+func (r *Repository) deepen(n int) error {
+	cmd := exec.Command("git", "fetch", "--quiet", fmt.Sprintf("--deepen=%d", n))
+	cmd.Dir = r.Path
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%v: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
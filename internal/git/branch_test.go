@@ -0,0 +1,50 @@
+package git
+
+import (
+	"os/exec"
+	"testing"
+)
+
+// run runs git in the repository and fails the test on error
+func run(t *testing.T, repo *Repository, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repo.Path
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v: %s", args, err, out)
+	}
+}
+
+func TestResolveRef(t *testing.T) {
+	repo := newTestRepository(t)
+	run(t, repo, "-c", "user.name=Test", "-c", "user.email=test@example.com",
+		"commit", "--allow-empty", "-q", "-m", "feat: initial commit")
+	run(t, repo, "branch", "feature/local")
+	for _, ref := range []string{"develop", "release/1.0", "feature/local"} {
+		run(t, repo, "update-ref", "refs/remotes/origin/"+ref, "HEAD")
+	}
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"feature/local", "feature/local"},
+		{"develop", "origin/develop"},
+		{"release/1.0", "origin/release/1.0"},
+		{"origin/release/1.0", "origin/release/1.0"},
+	}
+	for _, tt := range tests {
+		got, err := repo.ResolveRef(tt.name)
+		if err != nil {
+			t.Errorf("ResolveRef(%q): %v", tt.name, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ResolveRef(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	if _, err := repo.ResolveRef("hotfix/missing"); err == nil {
+		t.Error("resolved a branch that does not exist")
+	}
+}
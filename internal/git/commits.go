@@ -9,13 +9,35 @@ import (
 
 // Commit represents a Git commit
 type Commit struct {
-	Hash      string
-	Author    string
-	Date      string
-	Message   string
-	ShortHash string
+	Hash        string
+	Author      string
+	AuthorEmail string
+	Date        string
+	Message     string
+	Body        string
+	ShortHash   string
+	Parents     []string
 }
 
+// IsMerge reports whether the commit has more than one parent
+func (c *Commit) IsMerge() bool {
+	return len(c.Parents) > 1
+}
+
+// FullMessage returns the subject and body joined as Git stores them
+func (c *Commit) FullMessage() string {
+	if c.Body == "" {
+		return c.Message
+	}
+	return c.Message + "\n\n" + c.Body
+}
+
+// Git log format: hash, short hash, parents, author, author email, date,
+// subject, body.
+// Fields are separated by the ASCII unit separator and records by the
+// record separator so that multi-line bodies survive parsing.
+const logFormat = "%H%x1f%h%x1f%P%x1f%an%x1f%ae%x1f%cd%x1f%s%x1f%b%x1e"
+
 // GetLastCommit returns the most recent commit
 func (r *Repository) GetLastCommit() (*Commit, error) {
 	commits, err := r.GetCommits(1)
@@ -32,90 +54,67 @@ func (r *Repository) GetLastCommit() (*Commit, error) {
 
 // GetCommits returns a list of commits
 func (r *Repository) GetCommits(limit int) ([]*Commit, error) {
-	// Git log format: hash|author|date|message
-	format := "%H|%an|%cd|%s"
-
-	cmd := exec.Command("git", "log",
-		"--pretty=format:"+format,
-		"--date=short",
-		"-n", strconv.Itoa(limit))
-	cmd.Dir = r.Path
-
-	output, err := cmd.Output()
+	commits, err := r.logCommits("-n", strconv.Itoa(limit))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get commits: %v", err)
 	}
 
-	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-	var commits []*Commit
-
-	for _, line := range lines {
-		if line == "" {
-			continue
-		}
-
-		parts := strings.SplitN(line, "|", 4)
-		if len(parts) != 4 {
-			continue
-		}
-
-		commit := &Commit{
-			Hash:    parts[0],
-			Author:  parts[1],
-			Date:    parts[2],
-			Message: parts[3],
-		}
-
-		// Get short hash
-		shortHashCmd := exec.Command("git", "rev-parse", "--short", commit.Hash)
-		shortHashCmd.Dir = r.Path
-		if shortHash, err := shortHashCmd.Output(); err == nil {
-			commit.ShortHash = strings.TrimSpace(string(shortHash))
-		}
+	return commits, nil
+}
 
-		commits = append(commits, commit)
+// GetCommitsInRange returns commits between two references
+func (r *Repository) GetCommitsInRange(fromRef, toRef string) ([]*Commit, error) {
+	commits, err := r.logCommits(fromRef + ".." + toRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commits in range: %v", err)
 	}
 
 	return commits, nil
 }
 
-// GetCommitsInRange returns commits between two references
-func (r *Repository) GetCommitsInRange(fromRef, toRef string) ([]*Commit, error) {
-	format := "%H|%an|%cd|%s"
+// logCommits runs git log with the given arguments and parses the output
+func (r *Repository) logCommits(args ...string) ([]*Commit, error) {
+	cmdArgs := append([]string{"log", "--pretty=format:" + logFormat, "--date=short"}, args...)
 
-	cmd := exec.Command("git", "log",
-		"--pretty=format:"+format,
-		"--date=short",
-		fromRef+".."+toRef)
+	cmd := exec.Command("git", cmdArgs...)
 	cmd.Dir = r.Path
 
 	output, err := cmd.Output()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get commits in range: %v", err)
+		return nil, err
 	}
 
-	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	return parseLog(string(output)), nil
+}
+
+// parseLog parses output produced with logFormat
+func parseLog(output string) []*Commit {
 	var commits []*Commit
 
-	for _, line := range lines {
-		if line == "" {
+	for _, record := range strings.Split(output, "\x1e") {
+		record = strings.TrimLeft(record, "\n")
+		if record == "" {
 			continue
 		}
 
-		parts := strings.SplitN(line, "|", 4)
-		if len(parts) != 4 {
+		parts := strings.SplitN(record, "\x1f", 8)
+		if len(parts) != 8 {
 			continue
 		}
 
 		commits = append(commits, &Commit{
-			Hash:    parts[0],
-			Author:  parts[1],
-			Date:    parts[2],
-			Message: parts[3],
+			Hash:        parts[0],
+			ShortHash:   parts[1],
+			Parents:     strings.Fields(parts[2]),
+			Author:      parts[3],
+			AuthorEmail: parts[4],
+			Date:        parts[5],
+			Message:     parts[6],
+			Body:        strings.TrimSpace(parts[7]),
 		})
 	}
 
-	return commits, nil
+	return commits
 }
 
 // GetCurrentBranch returns the current branch name
@@ -143,3 +142,103 @@ func (r *Repository) GetCommitMessage(hash string) (string, error) {
 
 	return strings.TrimSpace(string(output)), nil
 }
+
+// GetRemoteURL returns a browsable HTTPS URL for the given remote, or an
+// empty string if the remote is not configured
+func (r *Repository) GetRemoteURL(remote string) string {
+	cmd := exec.Command("git", "config", "--get", "remote."+remote+".url")
+	cmd.Dir = r.Path
+
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+
+	url := strings.TrimSpace(string(output))
+	url = strings.TrimSuffix(url, ".git")
+
+	// git@github.com:owner/repo -> https://github.com/owner/repo
+	if strings.HasPrefix(url, "git@") {
+		url = "https://" + strings.Replace(strings.TrimPrefix(url, "git@"), ":", "/", 1)
+	}
+	url = strings.Replace(url, "ssh://git@", "https://", 1)
+
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return ""
+	}
+
+	return url
+}
+
+// GetTagsMerged returns the tags reachable from the given reference
+func (r *Repository) GetTagsMerged(ref string) ([]string, error) {
+	cmd := exec.Command("git", "tag", "--merged", ref)
+	cmd.Dir = r.Path
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %v", err)
+	}
+
+	var tags []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if tag := strings.TrimSpace(line); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags, nil
+}
+
+// GetCommitsSince returns the commits reachable from toRef but not from
+// fromRef. An empty fromRef returns the full history of toRef.
+func (r *Repository) GetCommitsSince(fromRef, toRef string) ([]*Commit, error) {
+	if fromRef == "" {
+		commits, err := r.logCommits(toRef)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get commits: %v", err)
+		}
+		return commits, nil
+	}
+	return r.GetCommitsInRange(fromRef, toRef)
+}
+
+// GetRecentCommits returns the history of ref, newest first, limited to
+// commits after since (any date git log --since accepts) and to at most
+// limit commits. Empty since or a zero limit disable that bound.
+func (r *Repository) GetRecentCommits(ref, since string, limit int) ([]*Commit, error) {
+	args := []string{ref}
+	if since != "" {
+		args = append(args, "--since="+since)
+	}
+	if limit > 0 {
+		args = append(args, fmt.Sprintf("--max-count=%d", limit))
+	}
+
+	commits, err := r.logCommits(args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commits: %v", err)
+	}
+	return commits, nil
+}
+
+// ReadFile returns the content of path in the tree of ref, and false if
+// the file does not exist there
+func (r *Repository) ReadFile(ref, path string) ([]byte, bool, error) {
+	spec := ref + ":" + path
+
+	check := exec.Command("git", "cat-file", "-e", spec)
+	check.Dir = r.Path
+	if check.Run() != nil {
+		return nil, false, nil
+	}
+
+	cmd := exec.Command("git", "cat-file", "blob", spec)
+	cmd.Dir = r.Path
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read %s: %v", spec, err)
+	}
+	return output, true, nil
+}
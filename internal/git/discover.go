@@ -0,0 +1,69 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DiscoverRepositories finds Git repositories under root, looking at most
+// maxDepth directories deep. Both working trees (with a .git directory or
+// file) and bare repositories such as mirrors are found. Repositories are
+// not searched for nested ones, and hidden directories are skipped.
+func DiscoverRepositories(root string, maxDepth int) ([]string, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, fmt.Errorf("cannot scan %s: %v", root, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("cannot scan %s: not a directory", root)
+	}
+
+	var repos []string
+	var walk func(dir string, depth int)
+	walk = func(dir string, depth int) {
+		if isRepositoryDir(dir) {
+			repos = append(repos, dir)
+			return
+		}
+		if depth >= maxDepth {
+			return
+		}
+
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return // unreadable directories are skipped
+		}
+		for _, e := range entries {
+			if !e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+				continue
+			}
+			walk(filepath.Join(dir, e.Name()), depth+1)
+		}
+	}
+	walk(root, 0)
+
+	sort.Strings(repos)
+	return repos, nil
+}
+
+// isRepositoryDir reports whether dir is the top of a working tree or a
+// bare repository
+func isRepositoryDir(dir string) bool {
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+		return true
+	}
+
+	// Bare repositories hold HEAD, objects and refs directly
+	if info, err := os.Stat(filepath.Join(dir, "HEAD")); err != nil || info.IsDir() {
+		return false
+	}
+	for _, sub := range []string{"objects", "refs"} {
+		if info, err := os.Stat(filepath.Join(dir, sub)); err != nil || !info.IsDir() {
+			return false
+		}
+	}
+	return true
+}
@@ -16,8 +16,61 @@ type Hook struct {
 	Enabled bool
 }
 
-// InstallCommitMsgHook installs the commit-msg hook
-func (r *Repository) InstallCommitMsgHook(force bool) error {
+// Hook names supported by commit-lint
+const (
+	HookCommitMsg = "commit-msg"
+	HookPrePush   = "pre-push"
+)
+
+// SupportedHooks lists the hooks commit-lint can install
+var SupportedHooks = []string{HookCommitMsg, HookPrePush}
+
+// Markers delimiting the block commit-lint manages inside a hook script.
+// Everything outside the markers belongs to the user or another tool.
+const (
+	blockStart = "# >>> commit-lint >>>"
+	blockEnd   = "# <<< commit-lint <<<"
+)
+
+// chainedSuffix is appended to a non-shell hook that commit-lint wraps
+const chainedSuffix = ".commit-lint-chained"
+
+// versionPrefix precedes the version of commit-lint that wrote a hook
+const versionPrefix = "# commit-lint-version: "
+
+// Policies for a hook that cannot find the commit-lint binary
+const (
+	MissingBinaryFail = "fail"
+	MissingBinaryWarn = "warn"
+)
+
+// HookOptions controls the generated hook script
+type HookOptions struct {
+	// Binary is the absolute path of the commit-lint executable; the hook
+	// falls back to PATH if it no longer exists
+	Binary string
+	// Version is stamped into the hook so stale hooks can be detected
+	Version string
+	// MissingBinary is MissingBinaryFail or MissingBinaryWarn
+	MissingBinary string
+}
+
+// legacySignature identifies hooks written by versions that replaced the
+// whole file instead of managing a block
+const legacySignature = "generated by commit-lint"
+
+// InstallHook installs the named hook. An existing shell hook keeps its
+// content and gets the commit-lint block inserted right after the shebang;
+// any other existing hook is moved aside and chained from a new script.
+func (r *Repository) InstallHook(name string, force bool, opts HookOptions) error {
+	if opts.MissingBinary != MissingBinaryFail && opts.MissingBinary != MissingBinaryWarn {
+		return fmt.Errorf("invalid missing-binary policy %q (use %s or %s)",
+			opts.MissingBinary, MissingBinaryFail, MissingBinaryWarn)
+	}
+	if _, err := hookBlock(name, "", opts); err != nil {
+		return err
+	}
+
 	hooksDir, err := r.GetHooksDir()
 	if err != nil {
 		return fmt.Errorf("failed to get hooks directory: %v", err)
@@ -28,123 +81,322 @@ func (r *Repository) InstallCommitMsgHook(force bool) error {
 		return fmt.Errorf("failed to create hooks directory: %v", err)
 	}
 
-	hookPath := filepath.Join(hooksDir, "commit-msg")
+	hookPath := filepath.Join(hooksDir, name)
 
-	// Check if hook already exists
-	if _, err := os.Stat(hookPath); err == nil && !force {
-		return fmt.Errorf("hook already exists at %s. Use --force to overwrite", hookPath)
+	data, err := os.ReadFile(hookPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read existing hook: %v", err)
 	}
+	existing := string(data)
+
+	// Legacy hooks were entirely ours, so they are replaced. The user's hook
+	// they kept in .backup goes back in place to be chained like any other.
+	if strings.Contains(existing, legacySignature) && !strings.Contains(existing, blockStart) {
+		if !force {
+			return fmt.Errorf("an older commit-lint hook exists at %s. Use --force to upgrade", hookPath)
+		}
+		existing = ""
 
-	// Create backup if exists
-	if _, err := os.Stat(hookPath); err == nil {
 		backupPath := hookPath + ".backup"
-		if err := copyFile(hookPath, backupPath); err != nil {
-			return fmt.Errorf("failed to backup existing hook: %v", err)
+		if backup, err := os.ReadFile(backupPath); err == nil {
+			if err := os.Rename(backupPath, hookPath); err != nil {
+				return fmt.Errorf("failed to restore backup: %v", err)
+			}
+			existing = string(backup)
+			fmt.Printf("✓ Restored original hook from %s\n", backupPath)
 		}
-		fmt.Printf("Backed up existing hook to: %s\n", backupPath)
 	}
 
-	// Generate hook content
-	hookContent := generateHookContent()
+	var content string
+	switch {
+	case strings.Contains(existing, blockStart):
+		if !force {
+			return fmt.Errorf("commit-lint is already installed in %s. Use --force to update", hookPath)
+		}
+		chained := ""
+		if _, err := os.Stat(hookPath + chainedSuffix); err == nil {
+			chained = hookPath + chainedSuffix
+		}
+		block, _ := hookBlock(name, chained, opts)
+		content = replaceBlock(existing, block)
+
+	case strings.TrimSpace(existing) == "":
+		block, _ := hookBlock(name, "", opts)
+		content = "#!/bin/sh\n" + block
+
+	case isShellScript(existing):
+		block, _ := hookBlock(name, "", opts)
+		content = insertBlock(existing, block)
+		fmt.Printf("✓ Chained into existing %s hook\n", name)
+
+	default:
+		// Not a shell script: move it aside and call it from our block
+		chained := hookPath + chainedSuffix
+		if err := os.Rename(hookPath, chained); err != nil {
+			return fmt.Errorf("failed to move existing hook: %v", err)
+		}
+		block, _ := hookBlock(name, chained, opts)
+		content = "#!/bin/sh\n" + block
+		fmt.Printf("✓ Existing hook moved to %s and chained\n", chained)
+	}
 
-	// Write hook file
-	if err := os.WriteFile(hookPath, []byte(hookContent), 0755); err != nil {
+	if err := os.WriteFile(hookPath, []byte(content), 0755); err != nil {
 		return fmt.Errorf("failed to write hook file: %v", err)
 	}
 
-	fmt.Printf("✓ Installed commit-msg hook at: %s\n", hookPath)
+	fmt.Printf("✓ Installed %s hook at: %s\n", name, hookPath)
 	return nil
 }
 
-// UninstallCommitMsgHook removes the commit-msg hook
-func (r *Repository) UninstallCommitMsgHook() error {
+// UninstallHook removes the commit-lint block from the named hook, leaving
+// any other content in place
+func (r *Repository) UninstallHook(name string) error {
 	hooksDir, err := r.GetHooksDir()
 	if err != nil {
 		return err
 	}
 
-	hookPath := filepath.Join(hooksDir, "commit-msg")
+	hookPath := filepath.Join(hooksDir, name)
 
-	// Check if our hook is installed
-	content, err := os.ReadFile(hookPath)
+	data, err := os.ReadFile(hookPath)
 	if err != nil {
 		return fmt.Errorf("no hook found at %s", hookPath)
 	}
+	content := string(data)
 
-	if !strings.Contains(string(content), "commit-lint") {
+	if !strings.Contains(content, blockStart) {
+		if strings.Contains(content, legacySignature) {
+			return r.uninstallLegacyHook(hookPath)
+		}
 		return fmt.Errorf("hook at %s is not a commit-lint hook", hookPath)
 	}
 
-	// Restore backup if exists
+	// A wrapped non-shell hook goes back to where it was
+	chained := hookPath + chainedSuffix
+	if _, err := os.Stat(chained); err == nil {
+		if err := os.Rename(chained, hookPath); err != nil {
+			return fmt.Errorf("failed to restore chained hook: %v", err)
+		}
+		fmt.Printf("✓ Restored original hook from %s\n", chained)
+		return nil
+	}
+
+	remaining := replaceBlock(content, "")
+	if isEmptyScript(remaining) {
+		if err := os.Remove(hookPath); err != nil {
+			return fmt.Errorf("failed to remove hook: %v", err)
+		}
+		fmt.Printf("✓ Removed commit-lint hook\n")
+		return nil
+	}
+
+	if err := os.WriteFile(hookPath, []byte(remaining), 0755); err != nil {
+		return fmt.Errorf("failed to update hook: %v", err)
+	}
+	fmt.Printf("✓ Removed commit-lint block, kept the rest of %s\n", hookPath)
+	return nil
+}
+
+// uninstallLegacyHook removes a hook written by an older version, restoring
+// the backup those versions kept
+func (r *Repository) uninstallLegacyHook(hookPath string) error {
 	backupPath := hookPath + ".backup"
 	if _, err := os.Stat(backupPath); err == nil {
 		if err := copyFile(backupPath, hookPath); err != nil {
 			return fmt.Errorf("failed to restore backup: %v", err)
 		}
 		fmt.Printf("✓ Restored original hook from backup\n")
-		// Remove backup
 		os.Remove(backupPath)
-	} else {
-		// No backup, just remove our hook
-		if err := os.Remove(hookPath); err != nil {
-			return fmt.Errorf("failed to remove hook: %v", err)
-		}
-		fmt.Printf("✓ Removed commit-lint hook\n")
+		return nil
 	}
 
+	if err := os.Remove(hookPath); err != nil {
+		return fmt.Errorf("failed to remove hook: %v", err)
+	}
+	fmt.Printf("✓ Removed commit-lint hook\n")
 	return nil
 }
 
-// IsHookInstalled checks if commit-lint hook is installed
-func (r *Repository) IsHookInstalled() (bool, error) {
+// IsHookInstalled checks if the named commit-lint hook is installed
+func (r *Repository) IsHookInstalled(name string) (bool, error) {
 	hooksDir, err := r.GetHooksDir()
 	if err != nil {
 		return false, err
 	}
 
-	hookPath := filepath.Join(hooksDir, "commit-msg")
+	hookPath := filepath.Join(hooksDir, name)
 	content, err := os.ReadFile(hookPath)
 	if err != nil {
 		return false, nil // File doesn't exist
 	}
 
-	return strings.Contains(string(content), "commit-lint"), nil
+	return strings.Contains(string(content), blockStart) ||
+		strings.Contains(string(content), legacySignature), nil
 }
 
-// generateHookContent creates the hook script
-func generateHookContent() string {
-	return `#!/bin/sh
-#
-# commit-msg hook generated by commit-lint
-# Validates commit messages against Conventional Commits
-#
-
-# Get the commit message file path
-COMMIT_MSG_FILE="$1"
-
-# Read the commit message
-COMMIT_MSG=$(cat "$COMMIT_MSG_FILE")
-
-# Use commit-lint to validate
-if command -v commit-lint >/dev/null 2>&1; then
-    commit-lint "$COMMIT_MSG"
-    EXIT_CODE=$?
-    
-    if [ $EXIT_CODE -ne 0 ]; then
-        echo ""
-        echo "❌ Commit message validation failed."
-        echo "   Please fix the message and try again."
-        echo "   To bypass (not recommended): git commit --no-verify"
+// isShellScript reports whether a hook can have shell code inserted
+func isShellScript(content string) bool {
+	firstLine := strings.SplitN(content, "\n", 2)[0]
+	if !strings.HasPrefix(firstLine, "#!") {
+		return false
+	}
+
+	fields := strings.Fields(strings.TrimPrefix(firstLine, "#!"))
+	if len(fields) == 0 {
+		return false
+	}
+	interpreter := filepath.Base(fields[0])
+	if interpreter == "env" && len(fields) > 1 {
+		interpreter = fields[1]
+	}
+
+	switch interpreter {
+	case "sh", "bash", "dash", "zsh", "ksh", "ash":
+		return true
+	}
+	return false
+}
+
+// isEmptyScript reports whether a script has nothing left but a shebang,
+// comments and blank lines
+func isEmptyScript(content string) bool {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			return false
+		}
+	}
+	return true
+}
+
+// insertBlock places the block right after the shebang so it runs before
+// the existing hook can exit
+func insertBlock(content, block string) string {
+	parts := strings.SplitN(content, "\n", 2)
+	rest := ""
+	if len(parts) > 1 {
+		rest = parts[1]
+	}
+	return parts[0] + "\n" + block + rest
+}
+
+// replaceBlock substitutes the managed block (markers included)
+func replaceBlock(content, block string) string {
+	start := strings.Index(content, blockStart)
+	end := strings.Index(content, blockEnd)
+	if start < 0 || end < start {
+		return content
+	}
+
+	end += len(blockEnd)
+	if end < len(content) && content[end] == '\n' {
+		end++
+	}
+
+	return content[:start] + block + content[end:]
+}
+
+// HookVersion returns the commit-lint version stamped into the named hook,
+// or an empty string if the hook has no stamp
+func (r *Repository) HookVersion(name string) (string, error) {
+	hooksDir, err := r.GetHooksDir()
+	if err != nil {
+		return "", err
+	}
+
+	content, err := os.ReadFile(filepath.Join(hooksDir, name))
+	if err != nil {
+		return "", err
+	}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		if strings.HasPrefix(line, versionPrefix) {
+			return strings.TrimSpace(strings.TrimPrefix(line, versionPrefix)), nil
+		}
+	}
+	return "", nil
+}
+
+// hookBlock returns the managed block for the named hook. If chained is
+// set, the block finishes by running that script with the same arguments.
+func hookBlock(name, chained string, opts HookOptions) (string, error) {
+	var body string
+	switch name {
+	case HookCommitMsg:
+		body = commitMsgHookBody
+	case HookPrePush:
+		body = prePushHookBody
+	default:
+		return "", fmt.Errorf("unsupported hook %q (supported: %s)", name, strings.Join(SupportedHooks, ", "))
+	}
+
+	missing := missingBinaryWarn
+	if opts.MissingBinary == MissingBinaryFail {
+		missing = missingBinaryFail
+	}
+
+	var b strings.Builder
+	b.WriteString(blockStart + "\n")
+	b.WriteString("# " + name + " hook generated by commit-lint; remove with: commit-lint --uninstall --hook " + name + "\n")
+	b.WriteString(versionPrefix + opts.Version + "\n")
+	b.WriteString("COMMIT_LINT=" + shellQuote(opts.Binary) + "\n")
+	b.WriteString(strings.Replace(resolveBinary, "{{missing}}", missing, 1))
+	b.WriteString(body)
+	if chained != "" {
+		b.WriteString("exec " + shellQuote(chained) + " \"$@\"\n")
+	}
+	b.WriteString(blockEnd + "\n")
+
+	return b.String(), nil
+}
+
+// shellQuote quotes s for use in a POSIX shell script
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// resolveBinary falls back to PATH when the binary that installed the hook
+// has moved. {{missing}} is replaced with the missing-binary policy.
+const resolveBinary = `if [ ! -x "$COMMIT_LINT" ]; then
+    COMMIT_LINT=$(command -v commit-lint 2>/dev/null)
+fi
+if [ -z "$COMMIT_LINT" ]; then
+{{missing}}fi
+`
+
+const missingBinaryFail = `    echo "❌ commit-lint not found (checked the install path and PATH)"
+    echo "   Install it with: go install github.com/ArjunSrivastava1/commit-linter/cmd/commit-lint@latest"
+    exit 1
+`
+
+const missingBinaryWarn = `    echo "⚠️  commit-lint not found (checked the install path and PATH); skipping validation"
+    echo "   Install it with: go install github.com/ArjunSrivastava1/commit-linter/cmd/commit-lint@latest"
+`
+
+// commitMsgHookBody validates the message file passed as $1 and exits on
+// failure, falling through to the rest of the hook on success
+const commitMsgHookBody = `if [ -n "$COMMIT_LINT" ] && ! "$COMMIT_LINT" --file "$1"; then
+    echo ""
+    echo "❌ Commit message validation failed."
+    echo "   Please fix the message and try again."
+    exit 1
+fi
+`
+
+// prePushHookBody validates the pushed refs read from stdin. The refs are
+// buffered in a temporary file that then becomes stdin again, so the rest
+// of the hook can still read them.
+const prePushHookBody = `if [ -n "$COMMIT_LINT" ]; then
+    COMMIT_LINT_REFS=$(mktemp) || exit 1
+    cat > "$COMMIT_LINT_REFS"
+    if ! "$COMMIT_LINT" pre-push "$@" < "$COMMIT_LINT_REFS"; then
+        rm -f "$COMMIT_LINT_REFS"
         exit 1
     fi
-else
-    echo "⚠️  commit-lint not found in PATH"
-    echo "   Install it with: go install github.com/yourusername/commit-linter/cmd/commit-lint@latest"
+    exec < "$COMMIT_LINT_REFS"
+    rm -f "$COMMIT_LINT_REFS"
 fi
-
-exit 0
 `
-}
 
 // Helper function to copy files
 func copyFile(src, dst string) error {
@@ -0,0 +1,120 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newTestRepository initializes an empty repository in a temporary
+// directory
+func newTestRepository(t *testing.T) *Repository {
+	t.Helper()
+	dir := t.TempDir()
+	if out, err := exec.Command("git", "init", "-q", dir).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v: %s", err, out)
+	}
+	repo, err := NewRepository(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return repo
+}
+
+var testHookOptions = HookOptions{Binary: "/usr/local/bin/commit-lint", Version: "test", MissingBinary: MissingBinaryWarn}
+
+const legacyHook = "#!/bin/sh\n# Git hook generated by commit-lint\ncommit-lint --file \"$1\"\n"
+
+func writeHook(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readHook(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestUpgradeLegacyHookKeepsBackup(t *testing.T) {
+	tests := []struct {
+		name    string
+		backup  string
+		chained bool
+	}{
+		{"shell backup", "#!/bin/sh\necho user hook\n", false},
+		{"non-shell backup", "#!/usr/bin/env python3\nprint('user hook')\n", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newTestRepository(t)
+			hooksDir, err := repo.GetHooksDir()
+			if err != nil {
+				t.Fatal(err)
+			}
+			hookPath := filepath.Join(hooksDir, HookCommitMsg)
+			writeHook(t, hookPath, legacyHook)
+			writeHook(t, hookPath+".backup", tt.backup)
+
+			if err := repo.InstallHook(HookCommitMsg, false, testHookOptions); err == nil {
+				t.Fatal("upgraded a legacy hook without --force")
+			}
+			if err := repo.InstallHook(HookCommitMsg, true, testHookOptions); err != nil {
+				t.Fatal(err)
+			}
+
+			installed := readHook(t, hookPath)
+			if !strings.Contains(installed, blockStart) || strings.Contains(installed, "# Git hook generated") {
+				t.Errorf("hook was not upgraded:\n%s", installed)
+			}
+			if _, err := os.Stat(hookPath + ".backup"); !os.IsNotExist(err) {
+				t.Error("backup left behind after the upgrade")
+			}
+			if tt.chained {
+				if got := readHook(t, hookPath+chainedSuffix); got != tt.backup {
+					t.Errorf("chained hook = %q, want the backup", got)
+				}
+			} else if !strings.Contains(installed, "echo user hook") {
+				t.Errorf("backup was not inlined:\n%s", installed)
+			}
+
+			if err := repo.UninstallHook(HookCommitMsg); err != nil {
+				t.Fatal(err)
+			}
+			if got := readHook(t, hookPath); got != tt.backup {
+				t.Errorf("after uninstall hook = %q, want %q", got, tt.backup)
+			}
+		})
+	}
+}
+
+func TestUpgradeLegacyHookWithoutBackup(t *testing.T) {
+	repo := newTestRepository(t)
+	hooksDir, err := repo.GetHooksDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hookPath := filepath.Join(hooksDir, HookCommitMsg)
+	writeHook(t, hookPath, legacyHook)
+
+	if err := repo.InstallHook(HookCommitMsg, true, testHookOptions); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.UninstallHook(HookCommitMsg); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(hookPath); !os.IsNotExist(err) {
+		t.Error("hook left behind after uninstall")
+	}
+}
@@ -0,0 +1,60 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Version returns the installed Git version (e.g. "2.39.5")
+func Version() (string, error) {
+	output, err := exec.Command("git", "--version").Output()
+	if err != nil {
+		return "", fmt.Errorf("git is not available: %v", err)
+	}
+
+	// "git version 2.39.5" or "git version 2.39.5 (Apple Git-143)"
+	fields := strings.Fields(string(output))
+	if len(fields) < 3 {
+		return strings.TrimSpace(string(output)), nil
+	}
+	return fields[2], nil
+}
+
+// ListWorktrees returns the paths of all worktrees, the main one first
+func (r *Repository) ListWorktrees() ([]string, error) {
+	cmd := exec.Command("git", "worktree", "list", "--porcelain")
+	cmd.Dir = r.Path
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list worktrees: %v", err)
+	}
+
+	var worktrees []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if strings.HasPrefix(line, "worktree ") {
+			worktrees = append(worktrees, strings.TrimPrefix(line, "worktree "))
+		}
+	}
+	return worktrees, nil
+}
+
+// ListSubmodules returns the paths of the initialized submodules
+func (r *Repository) ListSubmodules() ([]string, error) {
+	cmd := exec.Command("git", "submodule", "--quiet", "foreach", "--recursive", "echo $displaypath")
+	cmd.Dir = r.Path
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list submodules: %v", err)
+	}
+
+	var submodules []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			submodules = append(submodules, line)
+		}
+	}
+	return submodules, nil
+}
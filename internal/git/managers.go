@@ -0,0 +1,252 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// HookManager identifies who owns the repository's Git hooks
+type HookManager string
+
+// Supported install targets
+const (
+	ManagerAuto      HookManager = "auto"
+	ManagerGit       HookManager = "git"
+	ManagerPreCommit HookManager = "pre-commit"
+	ManagerLefthook  HookManager = "lefthook"
+	ManagerHusky     HookManager = "husky"
+)
+
+// Managers lists the valid install targets
+var Managers = []HookManager{ManagerAuto, ManagerGit, ManagerPreCommit, ManagerLefthook, ManagerHusky}
+
+// lefthookFiles are the config file names lefthook looks up, in order
+var lefthookFiles = []string{"lefthook.yml", ".lefthook.yml", "lefthook.yaml", ".lefthook.yaml"}
+
+// DetectHookManager returns the hook manager configured in the repository,
+// or ManagerGit if hooks are managed directly
+func (r *Repository) DetectHookManager() HookManager {
+	if fileExists(filepath.Join(r.Path, ".pre-commit-config.yaml")) {
+		return ManagerPreCommit
+	}
+	if r.lefthookConfig() != "" {
+		return ManagerLefthook
+	}
+	if fileExists(filepath.Join(r.Path, ".husky")) {
+		return ManagerHusky
+	}
+	return ManagerGit
+}
+
+// InstallWithManager adds a commit-msg entry to the given hook manager's
+// configuration. ManagerGit installs the hook script directly.
+func (r *Repository) InstallWithManager(manager HookManager, force bool, opts HookOptions) error {
+	switch manager {
+	case ManagerAuto:
+		return r.InstallWithManager(r.DetectHookManager(), force, opts)
+	case ManagerGit:
+		return r.InstallHook(HookCommitMsg, force, opts)
+	case ManagerPreCommit:
+		return r.installPreCommit()
+	case ManagerLefthook:
+		return r.installLefthook()
+	case ManagerHusky:
+		return r.installHusky()
+	}
+	return fmt.Errorf("unknown install target %q", manager)
+}
+
+// preCommitEntry runs the commit-lint binary on PATH at the commit-msg stage
+const preCommitEntry = `  - repo: local
+    hooks:
+      - id: commit-lint
+        name: commit-lint
+        entry: commit-lint --file
+        language: system
+        stages: [commit-msg]
+`
+
+func (r *Repository) installPreCommit() error {
+	path := filepath.Join(r.Path, ".pre-commit-config.yaml")
+
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	content := string(data)
+
+	if strings.Contains(content, "commit-lint") {
+		return fmt.Errorf("%s already references commit-lint", path)
+	}
+
+	switch {
+	case strings.TrimSpace(content) == "":
+		content = "repos:\n" + preCommitEntry
+	case hasTopLevelKey(content, "repos") && lastTopLevelKey(content) == "repos":
+		content = ensureTrailingNewline(content) + reindentEntry(preCommitEntry, sequenceIndent(content))
+	default:
+		return fmt.Errorf("cannot safely edit %s; add this under repos:\n%s", path, preCommitEntry)
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %v", path, err)
+	}
+
+	fmt.Printf("✓ Added commit-lint to %s\n", path)
+	fmt.Println("  Enable the stage with: pre-commit install --hook-type commit-msg")
+	return nil
+}
+
+// lefthookEntry runs commit-lint on the message file lefthook passes as {1}
+const lefthookEntry = `commit-msg:
+  commands:
+    commit-lint:
+      run: commit-lint --file {1}
+`
+
+func (r *Repository) installLefthook() error {
+	path := r.lefthookConfig()
+	if path == "" {
+		path = filepath.Join(r.Path, lefthookFiles[0])
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	content := string(data)
+
+	if strings.Contains(content, "commit-lint") {
+		return fmt.Errorf("%s already references commit-lint", path)
+	}
+	if hasTopLevelKey(content, "commit-msg") {
+		return fmt.Errorf("%s already has a commit-msg section; add this command to it:\n%s", path, lefthookEntry)
+	}
+
+	if content != "" {
+		content = ensureTrailingNewline(content) + "\n"
+	}
+	content += lefthookEntry
+
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %v", path, err)
+	}
+
+	fmt.Printf("✓ Added commit-lint to %s\n", path)
+	fmt.Println("  Activate it with: lefthook install")
+	return nil
+}
+
+// huskyLine validates the message file husky passes as $1
+const huskyLine = `commit-lint --file "$1"`
+
+func (r *Repository) installHusky() error {
+	dir := filepath.Join(r.Path, ".husky")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %v", dir, err)
+	}
+
+	path := filepath.Join(dir, HookCommitMsg)
+
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	content := string(data)
+
+	if strings.Contains(content, "commit-lint") {
+		return fmt.Errorf("%s already runs commit-lint", path)
+	}
+
+	content = ensureTrailingNewline(content) + huskyLine + "\n"
+	if strings.TrimSpace(string(data)) == "" {
+		content = huskyLine + "\n"
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0755); err != nil {
+		return fmt.Errorf("failed to write %s: %v", path, err)
+	}
+
+	fmt.Printf("✓ Added commit-lint to %s\n", path)
+	return nil
+}
+
+// lefthookConfig returns the path of an existing lefthook config file
+func (r *Repository) lefthookConfig() string {
+	for _, name := range lefthookFiles {
+		path := filepath.Join(r.Path, name)
+		if fileExists(path) {
+			return path
+		}
+	}
+	return ""
+}
+
+// hasTopLevelKey reports whether a YAML document has the given key at
+// column zero
+func hasTopLevelKey(content, key string) bool {
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(line, key+":") {
+			return true
+		}
+	}
+	return false
+}
+
+// lastTopLevelKey returns the last key at column zero of a YAML document,
+// so we know whether appending indented lines extends that key
+func lastTopLevelKey(content string) string {
+	last := ""
+	for _, line := range strings.Split(content, "\n") {
+		if line == "" || line[0] == ' ' || line[0] == '\t' || line[0] == '#' || line[0] == '-' {
+			continue
+		}
+		if idx := strings.Index(line, ":"); idx > 0 {
+			last = line[:idx]
+		}
+	}
+	return last
+}
+
+// sequenceIndent returns the indentation of the first sequence item in a
+// YAML document, defaulting to two spaces
+func sequenceIndent(content string) string {
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		if strings.HasPrefix(trimmed, "- ") {
+			return line[:len(line)-len(trimmed)]
+		}
+	}
+	return "  "
+}
+
+// reindentEntry shifts a two-space indented sequence entry to the given
+// indentation so it matches the surrounding list
+func reindentEntry(entry, indent string) string {
+	if indent == "  " {
+		return entry
+	}
+
+	var b strings.Builder
+	for _, line := range strings.SplitAfter(entry, "\n") {
+		if line == "" {
+			continue
+		}
+		b.WriteString(indent + strings.TrimPrefix(line, "  "))
+	}
+	return b.String()
+}
+
+func ensureTrailingNewline(s string) string {
+	if s != "" && !strings.HasSuffix(s, "\n") {
+		return s + "\n"
+	}
+	return s
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
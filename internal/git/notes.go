@@ -0,0 +1,144 @@
+package git
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ReadNotes returns the notes stored under ref, keyed by the hash of the
+// annotated commit. A missing notes ref yields no notes.
+func (r *Repository) ReadNotes(ref string) (map[string]string, error) {
+	cmd := exec.Command("git", "notes", "--ref", ref, "list")
+	cmd.Dir = r.Path
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notes: %v", err)
+	}
+
+	// "<note blob> <commit>" per line; identical notes share a blob
+	var blobs []string
+	commitsOf := map[string][]string{}
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		if commitsOf[fields[0]] == nil {
+			blobs = append(blobs, fields[0])
+		}
+		commitsOf[fields[0]] = append(commitsOf[fields[0]], fields[1])
+	}
+
+	notes := map[string]string{}
+	if len(blobs) == 0 {
+		return notes, nil
+	}
+
+	contents, err := r.readBlobs(blobs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read notes: %v", err)
+	}
+	for blob, content := range contents {
+		for _, commit := range commitsOf[blob] {
+			notes[commit] = content
+		}
+	}
+
+	return notes, nil
+}
+
+// readBlobs reads many blobs with a single git cat-file process
+func (r *Repository) readBlobs(blobs []string) (map[string]string, error) {
+	cmd := exec.Command("git", "cat-file", "--batch")
+	cmd.Dir = r.Path
+	cmd.Stdin = strings.NewReader(strings.Join(blobs, "\n") + "\n")
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	contents := map[string]string{}
+	reader := bufio.NewReader(strings.NewReader(string(output)))
+	for _, blob := range blobs {
+		// "<sha> <type> <size>" followed by the content and a newline
+		header, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("truncated cat-file output")
+		}
+		fields := strings.Fields(header)
+		if len(fields) != 3 {
+			continue // "<sha> missing"
+		}
+		size, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("malformed cat-file header %q", header)
+		}
+
+		content := make([]byte, size+1)
+		if _, err := io.ReadFull(reader, content); err != nil {
+			return nil, fmt.Errorf("truncated cat-file output")
+		}
+		contents[blob] = string(content[:size])
+	}
+
+	return contents, nil
+}
+
+// AddNotes attaches notes, keyed by commit hash, under the fully qualified
+// ref, replacing existing notes of those commits. All notes are written by
+// a single git fast-import process as one notes commit.
+func (r *Repository) AddNotes(ref string, notes map[string]string, message string) error {
+	if len(notes) == 0 {
+		return nil
+	}
+
+	ident := exec.Command("git", "var", "GIT_COMMITTER_IDENT")
+	ident.Dir = r.Path
+	committer, err := ident.Output()
+	if err != nil {
+		return fmt.Errorf("failed to determine the committer identity: %v", err)
+	}
+
+	var stream strings.Builder
+	fmt.Fprintf(&stream, "commit %s\ncommitter %s\n", ref, strings.TrimSpace(string(committer)))
+	writeData(&stream, message)
+	// Without a parent, fast-import refuses to move an existing ref
+	parent := exec.Command("git", "rev-parse", "--verify", "--quiet", ref)
+	parent.Dir = r.Path
+	if err := parent.Run(); err == nil {
+		fmt.Fprintf(&stream, "from %s^0\n", ref)
+	}
+
+	hashes := make([]string, 0, len(notes))
+	for hash := range notes {
+		hashes = append(hashes, hash)
+	}
+	sort.Strings(hashes)
+	for _, hash := range hashes {
+		fmt.Fprintf(&stream, "N inline %s\n", hash)
+		writeData(&stream, notes[hash])
+	}
+	stream.WriteString("done\n")
+
+	cmd := exec.Command("git", "fast-import", "--quiet", "--done")
+	cmd.Dir = r.Path
+	cmd.Stdin = strings.NewReader(stream.String())
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to add notes: %v\n%s", err, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
+// writeData writes a fast-import data command with an exact byte count
+func writeData(w io.Writer, content string) {
+	fmt.Fprintf(w, "data %d\n%s\n", len(content), content)
+}
@@ -0,0 +1,66 @@
+package git
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+const testNotesRef = "refs/notes/commit-lint"
+
+// commitAll creates n empty commits and returns their hashes
+func commitAll(t *testing.T, repo *Repository, n int) []string {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		run(t, repo, "-c", "user.name=Test", "-c", "user.email=test@example.com",
+			"commit", "--allow-empty", "-q", "-m", "feat: commit")
+	}
+	commits, err := repo.GetCommits(n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hashes := make([]string, len(commits))
+	for i, c := range commits {
+		hashes[i] = c.Hash
+	}
+	return hashes
+}
+
+func TestAddNotes(t *testing.T) {
+	repo := newTestRepository(t)
+	t.Setenv("GIT_COMMITTER_NAME", "Test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+	hashes := commitAll(t, repo, 3)
+
+	first := map[string]string{hashes[0]: "valid\n", hashes[1]: "invalid\nparse-failed\n", hashes[2]: "valid\n"}
+	if err := repo.AddNotes(testNotesRef, first, "first run"); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.AddNotes(testNotesRef, map[string]string{hashes[1]: "valid\n"}, "second run"); err != nil {
+		t.Fatal(err)
+	}
+
+	notes, err := repo.ReadNotes(testNotesRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{hashes[0]: "valid\n", hashes[1]: "valid\n", hashes[2]: "valid\n"}
+	for hash, content := range want {
+		if notes[hash] != content {
+			t.Errorf("note of %.7s = %q, want %q", hash, notes[hash], content)
+		}
+	}
+	if len(notes) != len(want) {
+		t.Errorf("%d notes, want %d", len(notes), len(want))
+	}
+
+	cmd := exec.Command("git", "rev-list", "--count", testNotesRef)
+	cmd.Dir = repo.Path
+	output, err := cmd.Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(string(output)); got != "2" {
+		t.Errorf("notes ref has %s commits, want one per AddNotes call", got)
+	}
+}
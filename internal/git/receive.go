@@ -0,0 +1,162 @@
+package git
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+)
+
+// ZeroSHA is the object name Git uses for a ref that does not exist
+const ZeroSHA = "0000000000000000000000000000000000000000"
+
+// RefUpdate is one line of pre-receive / pre-push input
+type RefUpdate struct {
+	OldSHA string
+	NewSHA string
+	Ref    string
+}
+
+// IsDelete reports whether the update deletes the ref
+func (u RefUpdate) IsDelete() bool {
+	return u.NewSHA == ZeroSHA
+}
+
+// IsCreate reports whether the update creates the ref
+func (u RefUpdate) IsCreate() bool {
+	return u.OldSHA == ZeroSHA
+}
+
+// IsBranch reports whether the ref is a branch
+func (u RefUpdate) IsBranch() bool {
+	return strings.HasPrefix(u.Ref, "refs/heads/")
+}
+
+// ParseRefUpdates reads "<old> <new> <ref>" lines as passed to the
+// pre-receive hook on stdin
+func ParseRefUpdates(r io.Reader) ([]RefUpdate, error) {
+	var updates []RefUpdate
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("malformed ref update: %q", scanner.Text())
+		}
+		updates = append(updates, RefUpdate{OldSHA: fields[0], NewSHA: fields[1], Ref: fields[2]})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read ref updates: %v", err)
+	}
+
+	return updates, nil
+}
+
+// GetPushedCommits returns the commits introduced by a ref update: those
+// reachable from the new value but from no existing ref. This covers new
+// branches and force pushes without re-checking commits already accepted.
+func (r *Repository) GetPushedCommits(update RefUpdate) ([]*Commit, error) {
+	if update.IsDelete() {
+		return nil, nil
+	}
+
+	commits, err := r.logCommits(update.NewSHA, "--not", "--all")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits for %s: %v", update.Ref, err)
+	}
+
+	return commits, nil
+}
+
+// PushUpdate is one line of pre-push hook input
+type PushUpdate struct {
+	LocalRef  string
+	LocalSHA  string
+	RemoteRef string
+	RemoteSHA string
+}
+
+// ParsePushUpdates reads "<local ref> <local sha> <remote ref> <remote sha>"
+// lines as passed to the pre-push hook on stdin
+func ParsePushUpdates(r io.Reader) ([]PushUpdate, error) {
+	var updates []PushUpdate
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("malformed push update: %q", scanner.Text())
+		}
+		updates = append(updates, PushUpdate{
+			LocalRef:  fields[0],
+			LocalSHA:  fields[1],
+			RemoteRef: fields[2],
+			RemoteSHA: fields[3],
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read push updates: %v", err)
+	}
+
+	return updates, nil
+}
+
+// GetUnpushedCommits returns the commits of a push update that the remote
+// does not have yet, judged by its remote-tracking refs and the remote SHA.
+// Git passes a URL instead of a name when pushing to an unnamed remote;
+// every remote-tracking ref is used then.
+func (r *Repository) GetUnpushedCommits(remote string, update PushUpdate) ([]*Commit, error) {
+	if update.LocalSHA == ZeroSHA {
+		return nil, nil
+	}
+
+	known := "--remotes"
+	if r.isRemote(remote) {
+		known = "--remotes=" + remote
+	}
+
+	args := []string{update.LocalSHA, "--not", known}
+	if update.RemoteSHA != ZeroSHA && r.hasCommit(update.RemoteSHA) {
+		args = append(args, update.RemoteSHA)
+	}
+
+	commits, err := r.logCommits(args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unpushed commits for %s: %v", update.LocalRef, err)
+	}
+
+	return commits, nil
+}
+
+// isRemote reports whether name is a configured remote
+func (r *Repository) isRemote(name string) bool {
+	cmd := exec.Command("git", "remote")
+	cmd.Dir = r.Path
+
+	output, err := cmd.Output()
+	if err != nil {
+		return false
+	}
+	for _, remote := range strings.Fields(string(output)) {
+		if remote == name {
+			return true
+		}
+	}
+	return false
+}
+
+// hasCommit reports whether the object exists locally and is a commit
+func (r *Repository) hasCommit(sha string) bool {
+	cmd := exec.Command("git", "cat-file", "-e", sha+"^{commit}")
+	cmd.Dir = r.Path
+	return cmd.Run() == nil
+}
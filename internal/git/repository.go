@@ -10,10 +10,24 @@ import (
 
 // Repository represents a Git repository
 type Repository struct {
+	// Path is where Git commands run: the top of the working tree, or the
+	// Git directory of a bare repository
 	Path string
+	// TopLevel is the root of the working tree; empty for bare repositories
+	TopLevel string
+	// GitDir is the per-worktree Git directory (holds COMMIT_EDITMSG). In a
+	// linked worktree this is .git/worktrees/<name> of the main repository.
+	GitDir string
+	// CommonDir is the Git directory shared by all worktrees (holds hooks,
+	// config and refs)
+	CommonDir string
+	// IsBare reports whether the repository has no working tree
+	IsBare bool
 }
 
-// NewRepository creates a new Repository instance
+// NewRepository creates a new Repository instance for the repository
+// containing path. Subdirectories, linked worktrees (where .git is a file)
+// and submodules are resolved through git rev-parse.
 func NewRepository(path string) (*Repository, error) {
 	// If no path provided, use current directory
 	if path == "" {
@@ -24,64 +38,93 @@ func NewRepository(path string) (*Repository, error) {
 		path = dir
 	}
 
-	// Check if it's a Git repository
-	if !isGitRepository(path) {
+	// --show-toplevel fails in bare repositories, so ask for it separately
+	lines, err := revParse(path, "--is-bare-repository", "--git-dir", "--git-common-dir")
+	if err != nil || len(lines) != 3 {
 		return nil, fmt.Errorf("not a Git repository: %s", path)
 	}
 
-	return &Repository{Path: path}, nil
-}
+	repo := &Repository{
+		IsBare:    lines[0] == "true",
+		GitDir:    absPath(path, lines[1]),
+		CommonDir: absPath(path, lines[2]),
+	}
 
-// isGitRepository checks if a directory is a Git repository
-func isGitRepository(path string) bool {
-	gitDir := filepath.Join(path, ".git")
-	if _, err := os.Stat(gitDir); err == nil {
-		return true
+	if repo.IsBare {
+		repo.Path = repo.GitDir
+		return repo, nil
 	}
 
-	// Check if we're inside a Git worktree
-	cmd := exec.Command("git", "rev-parse", "--git-dir")
-	cmd.Dir = path
-	if err := cmd.Run(); err == nil {
-		return true
+	lines, err = revParse(path, "--show-toplevel")
+	if err != nil || len(lines) != 1 {
+		return nil, fmt.Errorf("failed to find working tree root of %s", path)
 	}
+	repo.TopLevel = lines[0]
+	repo.Path = repo.TopLevel
 
-	return false
+	return repo, nil
 }
 
-// GetGitDir returns the .git directory path
-func (r *Repository) GetGitDir() (string, error) {
-	// First try .git directory
-	gitDir := filepath.Join(r.Path, ".git")
-	if _, err := os.Stat(gitDir); err == nil {
-		return gitDir, nil
-	}
+// revParse runs git rev-parse in dir and returns its output lines
+func revParse(dir string, args ...string) ([]string, error) {
+	cmd := exec.Command("git", append([]string{"rev-parse"}, args...)...)
+	cmd.Dir = dir
 
-	// Fallback to git command
-	cmd := exec.Command("git", "rev-parse", "--git-dir")
-	cmd.Dir = r.Path
 	output, err := cmd.Output()
 	if err != nil {
-		return "", fmt.Errorf("failed to get Git directory: %v", err)
+		return nil, err
 	}
 
-	dir := strings.TrimSpace(string(output))
-	if !filepath.IsAbs(dir) {
-		dir = filepath.Join(r.Path, dir)
+	return strings.Split(strings.TrimSpace(string(output)), "\n"), nil
+}
+
+// absPath resolves a path printed by git rev-parse, which may be relative
+// to the directory it ran in
+func absPath(dir, path string) string {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
 	}
+	return filepath.Clean(path)
+}
 
-	return dir, nil
+// GetGitDir returns the per-worktree Git directory
+func (r *Repository) GetGitDir() (string, error) {
+	return r.GitDir, nil
 }
 
-// GetHooksDir returns the hooks directory path
+// GetHooksDir returns the effective hooks directory, honoring
+// core.hooksPath when it is set
 func (r *Repository) GetHooksDir() (string, error) {
-	gitDir, err := r.GetGitDir()
+	if hooksPath := r.GetConfig("core.hooksPath"); hooksPath != "" {
+		if strings.HasPrefix(hooksPath, "~/") {
+			if home, err := os.UserHomeDir(); err == nil {
+				hooksPath = filepath.Join(home, hooksPath[2:])
+			}
+		}
+		// Relative paths are relative to where hooks run: the top of the
+		// working tree
+		if !filepath.IsAbs(hooksPath) {
+			hooksPath = filepath.Join(r.Path, hooksPath)
+		}
+		return hooksPath, nil
+	}
+
+	// Hooks are shared by all worktrees, so they live in the common dir
+	return filepath.Join(r.CommonDir, "hooks"), nil
+}
+
+// GetConfig returns the value of a Git config key, or an empty string if
+// it is not set
+func (r *Repository) GetConfig(key string) string {
+	cmd := exec.Command("git", "config", "--get", key)
+	cmd.Dir = r.Path
+
+	output, err := cmd.Output()
 	if err != nil {
-		return "", err
+		return ""
 	}
 
-	hooksDir := filepath.Join(gitDir, "hooks")
-	return hooksDir, nil
+	return strings.TrimSpace(string(output))
 }
 
 // GetCommitMessageFilePath returns the path to commit message file
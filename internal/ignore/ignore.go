@@ -0,0 +1,100 @@
+package ignore
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"commit-linter/internal/config"
+	"commit-linter/internal/git"
+)
+
+var (
+	// mergeMessageRe matches the default subjects of git merge and git
+	// pull, and of pull requests merged on GitHub. Parents are not always
+	// known (commit-msg hook, webhook payloads), so merges are also
+	// recognized by subject.
+	mergeMessageRe = regexp.MustCompile(`^Merge (branch|branches|remote-tracking branch|remote-tracking branches|tag|commit) '[^']+'|^Merge pull request #\d+ from \S`)
+	// revertSubjectRe matches the subject generated by git revert
+	revertSubjectRe = regexp.MustCompile(`^Revert ".*"$`)
+	// revertBodyRe matches the body line generated by git revert
+	revertBodyRe = regexp.MustCompile(`(?m)^This reverts commit [0-9a-f]{7,40}\.`)
+	// autosquashPrefixes are the subject prefixes used by git rebase --autosquash
+	autosquashPrefixes = []string{"fixup! ", "squash! ", "amend! "}
+)
+
+// Matcher decides whether a commit is exempt from validation
+type Matcher struct {
+	cfg      config.IgnoreConfig
+	authors  []*regexp.Regexp
+	patterns []*regexp.Regexp
+}
+
+// New compiles the ignore configuration
+func New(cfg config.IgnoreConfig) (*Matcher, error) {
+	m := &Matcher{cfg: cfg}
+
+	for _, expr := range cfg.Authors {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ignore author pattern %q: %v", expr, err)
+		}
+		m.authors = append(m.authors, re)
+	}
+
+	for _, expr := range cfg.Patterns {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ignore pattern %q: %v", expr, err)
+		}
+		m.patterns = append(m.patterns, re)
+	}
+
+	return m, nil
+}
+
+// Match reports whether a commit is ignored and why
+func (m *Matcher) Match(c *git.Commit) (string, bool) {
+	if m.cfg.Merges && c.IsMerge() {
+		return "merge commit", true
+	}
+
+	for _, re := range m.authors {
+		if re.MatchString(c.AuthorEmail) {
+			return "author " + c.AuthorEmail, true
+		}
+	}
+
+	return m.MatchMessage(c.FullMessage())
+}
+
+// MatchMessage applies the matchers that only need the message, which is
+// all that is available in the commit-msg hook
+func (m *Matcher) MatchMessage(message string) (string, bool) {
+	message = strings.TrimSpace(message)
+	subject := strings.SplitN(message, "\n", 2)[0]
+
+	if m.cfg.Merges && mergeMessageRe.MatchString(subject) {
+		return "merge commit", true
+	}
+
+	if m.cfg.Reverts && revertSubjectRe.MatchString(subject) && revertBodyRe.MatchString(message) {
+		return "git revert", true
+	}
+
+	if m.cfg.Autosquash {
+		for _, prefix := range autosquashPrefixes {
+			if strings.HasPrefix(subject, prefix) {
+				return strings.TrimSpace(prefix) + " commit", true
+			}
+		}
+	}
+
+	for _, re := range m.patterns {
+		if re.MatchString(message) {
+			return "pattern " + re.String(), true
+		}
+	}
+
+	return "", false
+}
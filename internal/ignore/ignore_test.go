@@ -0,0 +1,65 @@
+package ignore
+
+import (
+	"testing"
+
+	"commit-linter/internal/config"
+	"commit-linter/internal/git"
+)
+
+func TestMergeSubjects(t *testing.T) {
+	m, err := New(config.Default().Ignore)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		subject string
+		ignored bool
+	}{
+		{"Merge branch 'feature/login'", true},
+		{"Merge branch 'main' into feature/login", true},
+		{"Merge branch 'main' of github.com:octo-org/payments", true},
+		{"Merge branches 'a' and 'b'", true},
+		{"Merge remote-tracking branch 'origin/main'", true},
+		{"Merge tag 'v1.2.0'", true},
+		{"Merge commit 'a1b2c3d' into release", true},
+		{"Merge pull request #42 from octo-org/feature-login", true},
+		{"Merge sort into utils", false},
+		{"Merge config loading into one pass", false},
+		{"Merge branch handling into the router", false},
+		{"Merge pull request handling into the webhook", false},
+		{"feat: merge branch 'a' into 'b'", false},
+	}
+
+	for _, tt := range tests {
+		if _, ignored := m.MatchMessage(tt.subject); ignored != tt.ignored {
+			t.Errorf("MatchMessage(%q) ignored = %t, want %t", tt.subject, ignored, tt.ignored)
+		}
+	}
+}
+
+func TestMergeParents(t *testing.T) {
+	m, err := New(config.Default().Ignore)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	merge := &git.Commit{Message: "Sync with upstream", Parents: []string{"a1b2c3d", "e4f5a6b"}}
+	if reason, ok := m.Match(merge); !ok || reason != "merge commit" {
+		t.Errorf("merge with a custom subject: %q, %t", reason, ok)
+	}
+
+	single := &git.Commit{Message: "Merge sort into utils", Parents: []string{"a1b2c3d"}}
+	if reason, ok := m.Match(single); ok {
+		t.Errorf("single-parent commit ignored as %q", reason)
+	}
+
+	m, err = New(config.IgnoreConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := m.Match(merge); ok {
+		t.Error("merge ignored with ignore.merges off")
+	}
+}
@@ -0,0 +1,78 @@
+package linter
+
+import (
+	"regexp"
+	"strings"
+)
+
+// directiveRe matches a Lint-Disable trailer listing comma-separated rules
+var directiveRe = regexp.MustCompile(`(?mi)^Lint-Disable:[ \t]*(.+)$`)
+
+// ParseDirectives returns the rules named in Lint-Disable trailers
+func ParseDirectives(message string) []string {
+	var rules []string
+	for _, m := range directiveRe.FindAllStringSubmatch(message, -1) {
+		for _, rule := range strings.Split(m[1], ",") {
+			if rule = strings.TrimSpace(rule); rule != "" {
+				rules = append(rules, rule)
+			}
+		}
+	}
+	return rules
+}
+
+// applyDirectives suppresses the violations of rules disabled in the
+// message. Directives naming forbidden or unknown rules are reported as
+// warnings and have no effect.
+func (l *Linter) applyDirectives(message string, result *ValidationResult) {
+	requested := ParseDirectives(message)
+	if len(requested) == 0 {
+		return
+	}
+
+	if !l.opts.AllowDirectives {
+		result.Violations = append(result.Violations, Violation{
+			Rule:     "directive-forbidden",
+			Message:  "Lint-Disable directives are not allowed in this repository",
+			Level:    "warning",
+			severity: 1,
+		})
+		return
+	}
+
+	known := map[string]bool{"parse-failed": true}
+	for _, rule := range l.rules {
+		known[rule.Name] = true
+	}
+	forbidden := map[string]bool{}
+	for _, rule := range l.opts.ForbiddenDirectives {
+		forbidden[rule] = true
+	}
+
+	disabled := map[string]bool{}
+	var problems []Violation
+	for _, rule := range requested {
+		switch {
+		case !known[rule]:
+			problems = append(problems, Violation{
+				Rule:     "directive-unknown-rule",
+				Message:  "Lint-Disable names unknown rule '" + rule + "'",
+				Level:    "warning",
+				severity: 1,
+			})
+		case forbidden[rule]:
+			problems = append(problems, Violation{
+				Rule:     "directive-forbidden",
+				Message:  "Rule '" + rule + "' cannot be disabled with Lint-Disable",
+				Level:    "warning",
+				severity: 1,
+			})
+		case !disabled[rule]:
+			disabled[rule] = true
+			result.Disabled = append(result.Disabled, rule)
+		}
+	}
+
+	result.Exempted = append(result.Exempted, result.Suppress(disabled)...)
+	result.Violations = append(result.Violations, problems...)
+}
@@ -12,46 +12,96 @@ type CommitMessage struct {
 	Scope       string
 	Description string
 	Body        string
-	IsBreaking  bool
+	// Ticket is the issue key of Jira-prefixed headers
+	Ticket       string
+	IsBreaking   bool
+	BreakingNote string
 }
 
+// breakingFooterRe matches a BREAKING CHANGE footer in the message body
+var breakingFooterRe = regexp.MustCompile(`(?m)^BREAKING[ -]CHANGE: (.+)$`)
+
 // ParseCommitMessage parses a commit message using Conventional Commits format
 func ParseCommitMessage(message string) *CommitMessage {
+	msg, _ := parseMessage(message, parseConventionalHeader)
+	return msg
+}
+
+// parseMessage splits a message into header and body, parses the header
+// with parseHeader and reports whether it matched
+func parseMessage(message string, parseHeader func(string, *CommitMessage) bool) (*CommitMessage, bool) {
 	msg := &CommitMessage{Raw: message}
 
 	// Remove leading/trailing whitespace
 	message = strings.TrimSpace(message)
 
-	// Check for breaking change indicator
-	if strings.Contains(message, "!:") {
+	// The header is the first line; the body follows a blank line
+	header := message
+	if idx := strings.Index(message, "\n"); idx >= 0 {
+		header = strings.TrimSpace(message[:idx])
+		if parts := strings.SplitN(message, "\n\n", 2); len(parts) > 1 {
+			msg.Body = strings.TrimSpace(parts[1])
+		}
+	}
+
+	ok := parseHeader(header, msg)
+
+	// Check for BREAKING CHANGE footer
+	if footer := breakingFooterRe.FindStringSubmatch(msg.Body); footer != nil {
 		msg.IsBreaking = true
-		message = strings.Replace(message, "!:", ":", 1)
+		msg.BreakingNote = footer[1]
 	}
 
-	// Regex for conventional commits: type(scope): description
-	// Example: feat(auth): add login functionality
-	re := regexp.MustCompile(`^(\w+)(?:\(([^)]+)\))?!?: (.+)$`)
+	return msg, ok
+}
 
-	matches := re.FindStringSubmatch(message)
-	if matches != nil {
+// Regex for conventional commits: type(scope): description
+// Example: feat(auth): add login functionality
+var (
+	conventionalRe       = regexp.MustCompile(`^(\w+)(?:\(([^)]+)\))?!?: (.+)$`)
+	conventionalSimpleRe = regexp.MustCompile(`^(\w+): (.+)$`)
+)
+
+func parseConventionalHeader(header string, msg *CommitMessage) bool {
+	// Check for breaking change indicator
+	if strings.Contains(header, "!:") {
+		msg.IsBreaking = true
+		header = strings.Replace(header, "!:", ":", 1)
+	}
+
+	if matches := conventionalRe.FindStringSubmatch(header); matches != nil {
 		msg.Type = matches[1]
 		msg.Scope = matches[2]
 		msg.Description = matches[3]
+		return true
+	}
 
-		// Check for body (separated by two newlines)
-		parts := strings.SplitN(message, "\n\n", 2)
-		if len(parts) > 1 {
-			msg.Body = parts[1]
-		}
-	} else {
-		// Fallback: try to parse just type: description
-		reSimple := regexp.MustCompile(`^(\w+): (.+)$`)
-		simpleMatches := reSimple.FindStringSubmatch(message)
-		if simpleMatches != nil {
-			msg.Type = simpleMatches[1]
-			msg.Description = simpleMatches[2]
+	// Fallback: try to parse just type: description
+	if matches := conventionalSimpleRe.FindStringSubmatch(header); matches != nil {
+		msg.Type = matches[1]
+		msg.Description = matches[2]
+		return true
+	}
+
+	return false
+}
+
+// scissorsLine marks the start of the diff appended by git commit --verbose
+const scissorsLine = "# ------------------------ >8 ------------------------"
+
+// StripComments removes the comment lines Git adds to the commit message
+// file, along with everything after the scissors line
+func StripComments(message string) string {
+	if idx := strings.Index(message, scissorsLine); idx >= 0 {
+		message = message[:idx]
+	}
+
+	var lines []string
+	for _, line := range strings.Split(message, "\n") {
+		if !strings.HasPrefix(line, "#") {
+			lines = append(lines, line)
 		}
 	}
 
-	return msg
+	return strings.TrimSpace(strings.Join(lines, "\n"))
 }
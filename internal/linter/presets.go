@@ -0,0 +1,472 @@
+package linter
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DefaultPreset is the commit convention used when none is configured
+const DefaultPreset = "conventional"
+
+// Preset is a named commit convention: a header grammar and the rules
+// that go with it
+type Preset struct {
+	Name string
+	// Title names the convention in messages and URL documents it, if
+	// there is a specification
+	Title string
+	URL   string
+	// Format describes a valid header and Examples show complete ones
+	Format   string
+	Examples []string
+	// Release maps commit types to version bumps and changelog sections;
+	// nil for presets whose headers carry no type
+	Release *Release
+
+	parseHeader func(string, *CommitMessage) bool
+	rules       func() []Rule
+	// typeHint suggests types when one is missing or unknown; empty for
+	// presets whose headers carry no type
+	typeHint string
+	// header renders the header prefix for an example, or "" if commit
+	// has nothing to build one from
+	header func(*CommitMessage) string
+}
+
+// Release describes how a preset's commit types drive releases. Breaking
+// changes are marked by the parser and always bump the major version.
+type Release struct {
+	// Features bump the minor version and Fixes the patch version
+	Features []string
+	Fixes    []string
+	// Sections are the default changelog titles, in changelog order
+	Sections []Section
+}
+
+// Section is a changelog section collecting one commit type
+type Section struct {
+	Type  string
+	Title string
+}
+
+// IsFeature reports whether commits of type t bump the minor version
+func (r *Release) IsFeature(t string) bool {
+	return contains(r.Features, t)
+}
+
+// IsFix reports whether commits of type t bump the patch version
+func (r *Release) IsFix(t string) bool {
+	return contains(r.Fixes, t)
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse parses a commit message with the preset's header grammar and
+// reports whether the header matched
+func (p Preset) Parse(message string) (*CommitMessage, bool) {
+	return parseMessage(message, p.parseHeader)
+}
+
+// Rules returns the preset's validation rules
+func (p Preset) Rules() []Rule {
+	return p.rules()
+}
+
+// ParseFailedMessage is the message of the parse-failed violation
+func (p Preset) ParseFailedMessage() string {
+	return "Commit message doesn't follow " + p.Title + " format"
+}
+
+var presets = map[string]Preset{
+	conventionalPreset.Name: conventionalPreset,
+	angularPreset.Name:      angularPreset,
+	gitmojiPreset.Name:      gitmojiPreset,
+	jiraPreset.Name:         jiraPreset,
+	kernelPreset.Name:       kernelPreset,
+}
+
+// Presets returns the names of the available presets
+func Presets() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LookupPreset returns the named preset; an empty name selects the
+// default
+func LookupPreset(name string) (Preset, error) {
+	if name == "" {
+		name = DefaultPreset
+	}
+	preset, ok := presets[name]
+	if !ok {
+		return Preset{}, fmt.Errorf("unknown preset %q (available: %s)", name, strings.Join(Presets(), ", "))
+	}
+	return preset, nil
+}
+
+var conventionalTypes = []string{
+	"feat", "fix", "docs", "style",
+	"refactor", "test", "chore", "perf",
+}
+
+var conventionalPreset = Preset{
+	Name:   "conventional",
+	Title:  "Conventional Commits",
+	URL:    "https://www.conventionalcommits.org/",
+	Format: "type(scope): description",
+	Examples: []string{
+		"feat(auth): add login functionality",
+		"fix(api): resolve null pointer in user endpoint",
+		"docs(readme): update installation instructions",
+		"style(css): format button padding",
+		"refactor(auth): simplify token validation",
+		"test(login): add unit tests for authentication",
+		"chore(deps): update dependencies",
+	},
+	Release: &Release{
+		Features: []string{"feat"},
+		Fixes:    []string{"fix", "perf"},
+		Sections: []Section{
+			{"feat", "Features"},
+			{"fix", "Bug Fixes"},
+			{"perf", "Performance Improvements"},
+		},
+	},
+	parseHeader: parseConventionalHeader,
+	typeHint:    "Start with a valid type: " + strings.Join(conventionalTypes, ":, ") + ":",
+	rules: func() []Rule {
+		return joinRules(
+			typeRules(conventionalTypes,
+				"Commit type is required (feat, fix, docs, etc.)",
+				"Type must be one of: "+strings.Join(conventionalTypes, ", ")),
+			descriptionRules(72, "GitHub truncates"),
+			bodyRules(100),
+		)
+	},
+	header: typedHeader,
+}
+
+// angularTypes are the types of the Angular commit message guidelines
+var angularTypes = []string{
+	"build", "ci", "docs", "feat", "fix", "perf",
+	"refactor", "revert", "style", "test", "chore",
+}
+
+var angularPreset = Preset{
+	Name:   "angular",
+	Title:  "Angular",
+	URL:    "https://github.com/angular/angular/blob/main/CONTRIBUTING.md#commit",
+	Format: "type(scope): description",
+	Examples: []string{
+		"feat(auth): add login functionality",
+		"fix(api): resolve null pointer in user endpoint",
+		"build(deps): bump esbuild to 0.25",
+		"ci: cache Go modules between jobs",
+		"revert: feat(auth): add login functionality",
+		"docs(readme): update installation instructions",
+	},
+	Release: &Release{
+		Features: []string{"feat"},
+		Fixes:    []string{"fix", "perf"},
+		Sections: []Section{
+			{"feat", "Features"},
+			{"fix", "Bug Fixes"},
+			{"perf", "Performance Improvements"},
+			{"revert", "Reverts"},
+		},
+	},
+	parseHeader: parseConventionalHeader,
+	typeHint:    "Start with a valid type: " + strings.Join(angularTypes, ":, ") + ":",
+	rules: func() []Rule {
+		return joinRules(
+			typeRules(angularTypes,
+				"Commit type is required (feat, fix, build, ci, etc.)",
+				"Type must be one of: "+strings.Join(angularTypes, ", ")),
+			descriptionRules(72, "GitHub truncates"),
+			bodyRules(100),
+		)
+	},
+	header: typedHeader,
+}
+
+// typedHeader renders type(scope): headers
+func typedHeader(commit *CommitMessage) string {
+	switch {
+	case commit.Type == "":
+		return ""
+	case commit.Scope != "":
+		return commit.Type + "(" + commit.Scope + "): "
+	default:
+		return commit.Type + ": "
+	}
+}
+
+// gitmojis maps the gitmoji.dev codes to their emoji
+var gitmojis = map[string]string{
+	":adhesive_bandage:":          "🩹",
+	":airplane:":                  "✈️",
+	":alembic:":                   "⚗️",
+	":alien:":                     "👽️",
+	":ambulance:":                 "🚑️",
+	":arrow_down:":                "⬇️",
+	":arrow_up:":                  "⬆️",
+	":art:":                       "🎨",
+	":beers:":                     "🍻",
+	":bento:":                     "🍱",
+	":bookmark:":                  "🔖",
+	":boom:":                      "💥",
+	":bricks:":                    "🧱",
+	":bug:":                       "🐛",
+	":building_construction:":     "🏗️",
+	":bulb:":                      "💡",
+	":busts_in_silhouette:":       "👥",
+	":camera_flash:":              "📸",
+	":card_file_box:":             "🗃️",
+	":chart_with_upwards_trend:":  "📈",
+	":children_crossing:":         "🚸",
+	":closed_lock_with_key:":      "🔐",
+	":clown_face:":                "🤡",
+	":coffin:":                    "⚰️",
+	":construction:":              "🚧",
+	":construction_worker:":       "👷",
+	":dizzy:":                     "💫",
+	":egg:":                       "🥚",
+	":fire:":                      "🔥",
+	":globe_with_meridians:":      "🌐",
+	":goal_net:":                  "🥅",
+	":green_heart:":               "💚",
+	":hammer:":                    "🔨",
+	":heavy_minus_sign:":          "➖",
+	":heavy_plus_sign:":           "➕",
+	":iphone:":                    "📱",
+	":label:":                     "🏷️",
+	":lipstick:":                  "💄",
+	":lock:":                      "🔒️",
+	":loud_sound:":                "🔊",
+	":mag:":                       "🔍️",
+	":memo:":                      "📝",
+	":money_with_wings:":          "💸",
+	":monocle_face:":              "🧐",
+	":mute:":                      "🔇",
+	":necktie:":                   "👔",
+	":package:":                   "📦️",
+	":page_facing_up:":            "📄",
+	":passport_control:":          "🛂",
+	":pencil2:":                   "✏️",
+	":poop:":                      "💩",
+	":pushpin:":                   "📌",
+	":recycle:":                   "♻️",
+	":rewind:":                    "⏪️",
+	":rocket:":                    "🚀",
+	":rotating_light:":            "🚨",
+	":safety_vest:":               "🦺",
+	":see_no_evil:":               "🙈",
+	":seedling:":                  "🌱",
+	":sparkles:":                  "✨",
+	":speech_balloon:":            "💬",
+	":stethoscope:":               "🩺",
+	":tada:":                      "🎉",
+	":technologist:":              "🧑‍💻",
+	":test_tube:":                 "🧪",
+	":thread:":                    "🧵",
+	":triangular_flag_on_post:":   "🚩",
+	":truck:":                     "🚚",
+	":twisted_rightwards_arrows:": "🔀",
+	":wastebasket:":               "🗑️",
+	":wheelchair:":                "♿️",
+	":white_check_mark:":          "✅",
+	":wrench:":                    "🔧",
+	":zap:":                       "⚡️",
+}
+
+// gitmojiCodes maps each gitmoji, without variation selectors, to its code
+var gitmojiCodes = func() map[string]string {
+	codes := make(map[string]string, len(gitmojis))
+	for code, emoji := range gitmojis {
+		codes[strings.ReplaceAll(emoji, "\uFE0F", "")] = code
+	}
+	return codes
+}()
+
+// Regex for gitmoji headers: :code: or emoji, optional (scope), description
+// Example: :sparkles: (auth): add login functionality
+var gitmojiRe = regexp.MustCompile(`^(:[a-z0-9_+-]+:|\p{So}(?:\x{FE0F}|\x{200D}\p{So})*)\s*(?:\(([^)]+)\):?)?\s+(.+)$`)
+
+func parseGitmojiHeader(header string, msg *CommitMessage) bool {
+	matches := gitmojiRe.FindStringSubmatch(header)
+	if matches == nil {
+		return false
+	}
+
+	// Known emoji are reported by their code so both spellings agree
+	msg.Type = matches[1]
+	if code, ok := gitmojiCodes[strings.ReplaceAll(msg.Type, "\uFE0F", "")]; ok {
+		msg.Type = code
+	}
+	msg.Scope = matches[2]
+	msg.Description = matches[3]
+	if msg.Type == ":boom:" {
+		msg.IsBreaking = true
+	}
+	return true
+}
+
+var gitmojiPreset = Preset{
+	Name:   "gitmoji",
+	Title:  "gitmoji",
+	URL:    "https://gitmoji.dev/",
+	Format: ":gitmoji: (scope): description",
+	Examples: []string{
+		":sparkles: (auth): add login functionality",
+		":bug: resolve null pointer in user endpoint",
+		"📝 update installation instructions",
+		":recycle: (auth): simplify token validation",
+		":boom: drop support for the v1 API",
+	},
+	Release: &Release{
+		Features: []string{":sparkles:"},
+		Fixes:    []string{":bug:", ":ambulance:", ":zap:", ":lock:", ":adhesive_bandage:"},
+		Sections: []Section{
+			{":sparkles:", "✨ Features"},
+			{":bug:", "🐛 Bug Fixes"},
+			{":ambulance:", "🚑️ Critical Hotfixes"},
+			{":lock:", "🔒️ Security Fixes"},
+			{":zap:", "⚡️ Performance Improvements"},
+			{":adhesive_bandage:", "🩹 Minor Fixes"},
+		},
+	},
+	parseHeader: parseGitmojiHeader,
+	typeHint:    "Start with a gitmoji: :sparkles:, :bug:, :memo:, :recycle:, :white_check_mark:",
+	rules: func() []Rule {
+		codes := make([]string, 0, len(gitmojis))
+		for code := range gitmojis {
+			codes = append(codes, code)
+		}
+		return joinRules(
+			typeRules(codes,
+				"Header must start with a gitmoji (:sparkles:, ✨, :bug:, etc.)",
+				"Unknown gitmoji; see https://gitmoji.dev for the list"),
+			descriptionRules(72, "GitHub truncates"),
+			bodyRules(100),
+		)
+	},
+	header: func(commit *CommitMessage) string {
+		switch {
+		case commit.Type == "":
+			return ""
+		case commit.Scope != "":
+			return commit.Type + " (" + commit.Scope + "): "
+		default:
+			return commit.Type + " "
+		}
+	},
+}
+
+// Regex for Jira-prefixed headers: KEY-123: description
+// Example: PROJ-123: add login functionality
+var jiraRe = regexp.MustCompile(`^\[?([A-Z][A-Z0-9_]+-\d+)\]?:?\s+(.+)$`)
+
+func parseJiraHeader(header string, msg *CommitMessage) bool {
+	matches := jiraRe.FindStringSubmatch(header)
+	if matches == nil {
+		return false
+	}
+	msg.Ticket = matches[1]
+	msg.Description = matches[2]
+	return true
+}
+
+var jiraPreset = Preset{
+	Name:   "jira",
+	Title:  "Jira-prefixed",
+	Format: "PROJ-123: description",
+	Examples: []string{
+		"PROJ-123: add login functionality",
+		"PROJ-456: resolve null pointer in user endpoint",
+		"[OPS-78] rotate the staging certificates",
+	},
+	parseHeader: parseJiraHeader,
+	rules: func() []Rule {
+		return joinRules(
+			descriptionRules(72, "GitHub truncates"),
+			bodyRules(100),
+		)
+	},
+	header: func(commit *CommitMessage) string {
+		if commit.Ticket == "" {
+			return ""
+		}
+		return commit.Ticket + ": "
+	},
+}
+
+// Regex for kernel-style headers: subsystem: [subsystem: ...] description
+// Example: net: ipv4: fix checksum of fragmented packets
+var kernelRe = regexp.MustCompile(`^((?:[\w.,/+-]+: )*[\w.,/+-]+): (.+)$`)
+
+func parseKernelHeader(header string, msg *CommitMessage) bool {
+	matches := kernelRe.FindStringSubmatch(header)
+	if matches == nil {
+		return false
+	}
+	msg.Scope = matches[1]
+	msg.Description = matches[2]
+	return true
+}
+
+// signedOffRe matches a Developer Certificate of Origin trailer
+var signedOffRe = regexp.MustCompile(`(?m)^Signed-off-by: .+ <[^>]+>$`)
+
+var kernelPreset = Preset{
+	Name:   "kernel",
+	Title:  "Linux kernel",
+	URL:    "https://docs.kernel.org/process/submitting-patches.html",
+	Format: "subsystem: description",
+	Examples: []string{
+		"net: ipv4: fix checksum of fragmented packets",
+		"drm/i915: avoid a use-after-free on unbind",
+		"Documentation: fix typos in the locking guide",
+	},
+	parseHeader: parseKernelHeader,
+	rules: func() []Rule {
+		return joinRules(
+			descriptionRules(75, "git log --oneline"),
+			bodyRules(75),
+			[]Rule{{
+				Name: "signed-off-by",
+				Check: func(msg *CommitMessage) bool {
+					return signedOffRe.MatchString(msg.Body)
+				},
+				Message: "Signed-off-by trailer is required (git commit -s)",
+				Level:   "error",
+			}},
+		)
+	},
+	header: func(commit *CommitMessage) string {
+		if commit.Scope == "" {
+			return ""
+		}
+		return commit.Scope + ": "
+	},
+}
+
+func joinRules(groups ...[]Rule) []Rule {
+	var rules []Rule
+	for _, group := range groups {
+		rules = append(rules, group...)
+	}
+	return rules
+}
@@ -1,6 +1,7 @@
 package linter
 
 import (
+	"fmt"
 	"strings"
 )
 
@@ -10,32 +11,52 @@ type Rule struct {
 	Check   func(*CommitMessage) bool
 	Message string
 	Level   string // "error", "warning"
+	// Severity optionally grades a violation between 0 and 1 for partial
+	// credit; the rule's weight is scaled by it. Nil means full weight.
+	Severity func(*CommitMessage) float64
 }
 
 // Violation represents a rule violation
 type Violation struct {
-	Rule    string
-	Message string
-	Level   string
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+	Level   string `json:"level"`
+	// Penalty is the number of points the violation cost
+	Penalty int `json:"penalty"`
+
+	severity float64
 }
 
 // ValidationResult contains the validation outcome
 type ValidationResult struct {
-	IsValid     bool
-	Score       int
-	Violations  []Violation
-	Suggestions []string
+	IsValid     bool        `json:"valid"`
+	Score       int         `json:"score"`
+	Violations  []Violation `json:"violations"`
+	Suggestions []string    `json:"suggestions"`
+	// Disabled lists the rules turned off by Lint-Disable directives
+	Disabled []string `json:"disabled,omitempty"`
+	// Exempted holds the violations those directives suppressed, so
+	// reviewers can audit them
+	Exempted []Violation `json:"exempted,omitempty"`
+
+	scoring Scoring
 }
 
 // DefaultRules returns the standard validation rules
 func DefaultRules() []Rule {
+	return conventionalPreset.Rules()
+}
+
+// typeRules check the type of headers that have one; required and enum
+// are the messages shown when it is missing or unknown
+func typeRules(types []string, required, enum string) []Rule {
 	return []Rule{
 		{
 			Name: "type-required",
 			Check: func(msg *CommitMessage) bool {
 				return msg.Type != ""
 			},
-			Message: "Commit type is required (feat, fix, docs, etc.)",
+			Message: required,
 			Level:   "error",
 		},
 		{
@@ -49,20 +70,23 @@ func DefaultRules() []Rule {
 		{
 			Name: "type-enum",
 			Check: func(msg *CommitMessage) bool {
-				validTypes := []string{
-					"feat", "fix", "docs", "style",
-					"refactor", "test", "chore", "perf",
-				}
-				for _, validType := range validTypes {
+				for _, validType := range types {
 					if msg.Type == validType {
 						return true
 					}
 				}
 				return false
 			},
-			Message: "Type must be one of: feat, fix, docs, style, refactor, test, chore, perf",
+			Message: enum,
 			Level:   "error",
 		},
+	}
+}
+
+// descriptionRules check the description part of the header; reason
+// explains the length limit
+func descriptionRules(maxLength int, reason string) []Rule {
+	return []Rule{
 		{
 			Name: "description-required",
 			Check: func(msg *CommitMessage) bool {
@@ -78,14 +102,21 @@ func DefaultRules() []Rule {
 			},
 			Message: "Description must be at least 10 characters",
 			Level:   "warning",
+			Severity: func(msg *CommitMessage) float64 {
+				return float64(10-len(msg.Description)) / 10
+			},
 		},
 		{
 			Name: "description-max-length",
 			Check: func(msg *CommitMessage) bool {
-				return len(msg.Description) <= 72
+				return len(msg.Description) <= maxLength
 			},
-			Message: "Description should not exceed 72 characters (GitHub truncates)",
+			Message: fmt.Sprintf("Description should not exceed %d characters (%s)", maxLength, reason),
 			Level:   "warning",
+			// Full weight once the description is half as long again
+			Severity: func(msg *CommitMessage) float64 {
+				return float64(len(msg.Description)-maxLength) / float64(maxLength/2)
+			},
 		},
 		{
 			Name: "no-period",
@@ -117,3 +148,48 @@ func DefaultRules() []Rule {
 		},
 	}
 }
+
+// bodyRules check the message body
+func bodyRules(maxLineLength int) []Rule {
+	return []Rule{
+		{
+			Name: "body-leading-blank",
+			Check: func(msg *CommitMessage) bool {
+				lines := strings.Split(strings.TrimSpace(msg.Raw), "\n")
+				return len(lines) < 2 || strings.TrimSpace(lines[1]) == ""
+			},
+			Message: "Body must be separated from the header by a blank line",
+			Level:   "warning",
+		},
+		{
+			Name: "body-max-line-length",
+			Check: func(msg *CommitMessage) bool {
+				for _, line := range strings.Split(msg.Body, "\n") {
+					// Long URLs cannot be wrapped
+					if strings.Contains(line, "://") {
+						continue
+					}
+					if len(line) > maxLineLength {
+						return false
+					}
+				}
+				return true
+			},
+			Message: fmt.Sprintf("Body lines should not exceed %d characters", maxLineLength),
+			Level:   "warning",
+		},
+	}
+}
+
+// PullRequestExemptRules are the rules that only apply to commit messages.
+// Forges render pull request descriptions as Markdown, so their paragraphs
+// are rarely wrapped.
+func PullRequestExemptRules() map[string]bool {
+	return map[string]bool{"body-max-line-length": true}
+}
+
+// IsBodyRule reports whether the named rule checks the message body rather
+// than the header
+func IsBodyRule(name string) bool {
+	return strings.HasPrefix(name, "body-")
+}
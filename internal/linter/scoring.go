@@ -0,0 +1,83 @@
+package linter
+
+import (
+	"fmt"
+	"math"
+)
+
+// MinScoreRule is the pseudo-rule reported when a message scores below the
+// configured threshold
+const MinScoreRule = "min-score"
+
+// Scoring controls how violations translate into a 0-100 score
+type Scoring struct {
+	// Weights overrides the points deducted per rule
+	Weights map[string]int
+	// ErrorWeight is the default weight of error-level rules
+	ErrorWeight int
+	// WarningWeight is the default weight of warning-level rules
+	WarningWeight int
+	// MinScore fails validation when the score is below it, even without
+	// errors. Zero disables the threshold.
+	MinScore int
+}
+
+// DefaultScoring reproduces the classic model: any error scores 0 and each
+// warning costs 10 points
+func DefaultScoring() Scoring {
+	return Scoring{
+		Weights:       map[string]int{},
+		ErrorWeight:   100,
+		WarningWeight: 10,
+	}
+}
+
+// weight returns the full penalty of a violation before partial credit
+func (s Scoring) weight(v Violation) int {
+	if w, ok := s.Weights[v.Rule]; ok {
+		return w
+	}
+	if v.Level == "error" {
+		return s.ErrorWeight
+	}
+	return s.WarningWeight
+}
+
+// rescore derives penalties, score and validity from the violations
+func (r *ValidationResult) rescore() {
+	// Drop a threshold violation from an earlier scoring pass
+	kept := r.Violations[:0]
+	for _, v := range r.Violations {
+		if v.Rule != MinScoreRule {
+			kept = append(kept, v)
+		}
+	}
+	r.Violations = kept
+
+	r.IsValid = true
+	r.Score = 100
+
+	for i := range r.Violations {
+		v := &r.Violations[i]
+		if v.Level == "error" {
+			r.IsValid = false
+		}
+
+		severity := math.Min(math.Max(v.severity, 0), 1)
+		v.Penalty = int(math.Ceil(float64(r.scoring.weight(*v)) * severity))
+		r.Score -= v.Penalty
+	}
+
+	if r.Score < 0 {
+		r.Score = 0
+	}
+
+	if r.scoring.MinScore > 0 && r.Score < r.scoring.MinScore {
+		r.IsValid = false
+		r.Violations = append(r.Violations, Violation{
+			Rule:    MinScoreRule,
+			Message: fmt.Sprintf("Score %d is below the minimum of %d", r.Score, r.scoring.MinScore),
+			Level:   "error",
+		})
+	}
+}
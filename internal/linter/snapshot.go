@@ -0,0 +1,35 @@
+package linter
+
+// Snapshot is the serializable form of a ValidationResult. It carries the
+// per-violation severities that JSON output leaves out, so a restored
+// result can still be rescored when violations are suppressed.
+type Snapshot struct {
+	Result     ValidationResult `json:"result"`
+	Severities []float64        `json:"severities"`
+}
+
+// Snapshot captures the result for storage
+func (r *ValidationResult) Snapshot() Snapshot {
+	s := Snapshot{Result: *r}
+	s.Result.Violations = append([]Violation(nil), r.Violations...)
+	for _, v := range r.Violations {
+		s.Severities = append(s.Severities, v.severity)
+	}
+	return s
+}
+
+// Restore rebuilds a result captured by Snapshot, scored with this linter's
+// options. It returns false if the snapshot is inconsistent.
+func (l *Linter) Restore(s Snapshot) (*ValidationResult, bool) {
+	if len(s.Severities) != len(s.Result.Violations) {
+		return nil, false
+	}
+
+	result := s.Result
+	result.Violations = append([]Violation{}, s.Result.Violations...)
+	for i := range result.Violations {
+		result.Violations[i].severity = s.Severities[i]
+	}
+	result.scoring = l.opts.Scoring
+	return &result, true
+}
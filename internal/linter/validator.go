@@ -2,70 +2,135 @@ package linter
 
 import "strings"
 
-// Validate validates a commit message against rules
+// Options configures a Linter
+type Options struct {
+	// Preset selects the commit convention; the zero value means the
+	// default preset
+	Preset Preset
+	// Scoring controls how violations translate into a score
+	Scoring Scoring
+	// AllowDirectives enables Lint-Disable trailers in commit messages
+	AllowDirectives bool
+	// ForbiddenDirectives lists rules that Lint-Disable may not turn off
+	ForbiddenDirectives []string
+}
+
+// DefaultForbiddenDirectives lists the rules Lint-Disable may not turn off
+// unless configured otherwise: disabling them would accept any message
+var DefaultForbiddenDirectives = []string{"parse-failed", "type-required"}
+
+// DefaultOptions returns the options used by Validate
+func DefaultOptions() Options {
+	return Options{
+		Preset:              conventionalPreset,
+		Scoring:             DefaultScoring(),
+		AllowDirectives:     true,
+		ForbiddenDirectives: DefaultForbiddenDirectives,
+	}
+}
+
+// Linter validates commit messages with a fixed configuration
+type Linter struct {
+	opts  Options
+	rules []Rule
+}
+
+// New creates a Linter using the rules of opts.Preset
+func New(opts Options) *Linter {
+	if opts.Preset.parseHeader == nil {
+		opts.Preset = conventionalPreset
+	}
+	return &Linter{opts: opts, rules: opts.Preset.Rules()}
+}
+
+// Preset returns the commit convention the linter enforces
+func (l *Linter) Preset() Preset {
+	return l.opts.Preset
+}
+
+// Parse parses a commit message with the preset's header grammar
+func (l *Linter) Parse(message string) *CommitMessage {
+	commit, _ := l.opts.Preset.Parse(message)
+	return commit
+}
+
+// Validate validates a commit message against the default rules and options
 func Validate(message string) *ValidationResult {
+	return New(DefaultOptions()).Validate(message)
+}
+
+// Validate validates a commit message against rules
+func (l *Linter) Validate(message string) *ValidationResult {
 	result := &ValidationResult{
-		IsValid:    true,
-		Score:      100,
-		Violations: []Violation{},
+		scoring:     l.opts.Scoring,
+		IsValid:     true,
+		Score:       100,
+		Violations:  []Violation{},
+		Suggestions: []string{},
 	}
 
 	// Parse the commit message
-	commit := ParseCommitMessage(message)
+	commit, ok := l.opts.Preset.Parse(message)
 
 	// If we can't parse it at all, it's invalid
-	if commit.Type == "" && commit.Description == "" {
+	if !ok {
 		result.Violations = append(result.Violations, Violation{
-			Rule:    "parse-failed",
-			Message: "Commit message doesn't follow Conventional Commits format",
-			Level:   "error",
+			Rule:     "parse-failed",
+			Message:  l.opts.Preset.ParseFailedMessage(),
+			Level:    "error",
+			severity: 1,
 		})
-		result.IsValid = false
-		result.Score = 0
+		l.applyDirectives(message, result)
+		result.rescore()
 		return result
 	}
 
 	// Apply all rules
-	rules := DefaultRules()
-	errorCount := 0
-	warningCount := 0
-
-	for _, rule := range rules {
+	for _, rule := range l.rules {
 		if !rule.Check(commit) {
-			violation := Violation{
-				Rule:    rule.Name,
-				Message: rule.Message,
-				Level:   rule.Level,
-			}
-			result.Violations = append(result.Violations, violation)
-
-			if rule.Level == "error" {
-				result.IsValid = false
-				errorCount++
-			} else {
-				warningCount++
+			severity := 1.0
+			if rule.Severity != nil {
+				severity = rule.Severity(commit)
 			}
+			result.Violations = append(result.Violations, Violation{
+				Rule:     rule.Name,
+				Message:  rule.Message,
+				Level:    rule.Level,
+				severity: severity,
+			})
 		}
 	}
 
-	// Calculate score (simple formula)
-	if errorCount > 0 {
-		result.Score = 0
-	} else {
-		// Start at 100, deduct for warnings
-		result.Score = 100 - (warningCount * 10)
-		if result.Score < 0 {
-			result.Score = 0
-		}
-	}
+	l.applyDirectives(message, result)
+	result.rescore()
 
 	// Generate suggestions
-	result.Suggestions = generateSuggestions(commit, result.Violations)
+	result.Suggestions = generateSuggestions(l.opts.Preset, commit, result.Violations)
 
 	return result
 }
 
-func generateSuggestions(commit *CommitMessage, violations []Violation) []string {
+// Suppress removes violations of the given rules and recomputes validity
+// and score. It returns the suppressed violations.
+func (r *ValidationResult) Suppress(rules map[string]bool) []Violation {
+	kept := []Violation{}
+	var suppressed []Violation
+	for _, v := range r.Violations {
+		if rules[v.Rule] {
+			suppressed = append(suppressed, v)
+		} else {
+			kept = append(kept, v)
+		}
+	}
+
+	if len(suppressed) > 0 {
+		r.Violations = kept
+		r.rescore()
+	}
+	return suppressed
+}
+
+func generateSuggestions(preset Preset, commit *CommitMessage, violations []Violation) []string {
 	suggestions := []string{}
 
 	hasTypeIssue := false
@@ -75,6 +140,8 @@ func generateSuggestions(commit *CommitMessage, violations []Violation) []string
 		switch v.Rule {
 		case "type-required", "type-enum":
 			hasTypeIssue = true
+		case "signed-off-by":
+			suggestions = append(suggestions, "Add a Signed-off-by trailer with 'git commit -s'")
 		case "parse-failed":
 			hasFormatIssue = true
 		case "description-min-length":
@@ -84,24 +151,18 @@ func generateSuggestions(commit *CommitMessage, violations []Violation) []string
 		}
 	}
 
-	if hasTypeIssue {
-		suggestions = append(suggestions,
-			"Start with a valid type: feat:, fix:, docs:, style:, refactor:, test:, chore:")
+	if hasTypeIssue && preset.typeHint != "" {
+		suggestions = append(suggestions, preset.typeHint)
 	}
 
 	if hasFormatIssue {
 		suggestions = append(suggestions,
-			"Use format: type(scope): description\ne.g., feat(auth): add login functionality")
+			"Use format: "+preset.Format+"\ne.g., "+preset.Examples[0])
 	}
 
 	// Example suggestion
-	if commit.Type != "" && commit.Description != "" {
-		example := "Example: "
-		if commit.Scope != "" {
-			example += commit.Type + "(" + commit.Scope + "): "
-		} else {
-			example += commit.Type + ": "
-		}
+	if header := preset.header(commit); header != "" && commit.Description != "" {
+		example := "Example: " + header
 
 		// Improve the description
 		words := strings.Fields(commit.Description)
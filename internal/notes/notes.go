@@ -0,0 +1,92 @@
+package notes
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"commit-linter/internal/linter"
+)
+
+// Ref is the notes ref that lint results are stored under. Notes are not
+// pushed by default; share them with
+// git push origin refs/notes/commit-lint
+const Ref = "refs/notes/commit-lint"
+
+// Note is the lint result recorded for a commit
+type Note struct {
+	Tool    string `json:"tool"`
+	Version string `json:"version"`
+	// Fingerprint identifies the rule configuration that produced the
+	// result; see cache.Fingerprint
+	Fingerprint string `json:"fingerprint"`
+
+	// Status is valid, invalid or ignored
+	Status string `json:"status"`
+	// Ignored is the reason the commit was skipped by ignore rules
+	Ignored string `json:"ignored,omitempty"`
+	// Score and Rules (the violated rules) are set for linted commits
+	Score int      `json:"score"`
+	Rules []string `json:"rules,omitempty"`
+
+	// Result is the full result, used to skip re-validation
+	Result *linter.Snapshot `json:"result,omitempty"`
+}
+
+// New records a result. A nil result records an ignored commit.
+func New(version, fingerprint string, result *linter.ValidationResult, ignored string) Note {
+	n := Note{
+		Tool:        "commit-lint",
+		Version:     version,
+		Fingerprint: fingerprint,
+		Status:      "ignored",
+		Ignored:     ignored,
+	}
+
+	if result != nil {
+		n.Status = "invalid"
+		if result.IsValid {
+			n.Status = "valid"
+		}
+		n.Score = result.Score
+		for _, v := range result.Violations {
+			n.Rules = append(n.Rules, v.Rule)
+		}
+		snapshot := result.Snapshot()
+		n.Result = &snapshot
+	}
+
+	return n
+}
+
+// Parse decodes a note written by Encode
+func Parse(content string) (Note, error) {
+	var n Note
+	if err := json.Unmarshal([]byte(content), &n); err != nil {
+		return Note{}, fmt.Errorf("malformed commit-lint note: %v", err)
+	}
+	return n, nil
+}
+
+// Encode renders the note as indented JSON
+func (n Note) Encode() string {
+	data, _ := json.MarshalIndent(n, "", "  ")
+	return string(data) + "\n"
+}
+
+// Current reports whether the note was produced by this version with the
+// same rule configuration, so its result can be reused
+func (n Note) Current(version, fingerprint string) bool {
+	return n.Version == version && n.Fingerprint == fingerprint
+}
+
+// Summary describes the recorded outcome in one line
+func (n Note) Summary() string {
+	switch n.Status {
+	case "ignored":
+		return fmt.Sprintf("⏭️  ignored (%s), commit-lint %s", n.Ignored, n.Version)
+	case "valid":
+		return fmt.Sprintf("✅ valid (%d/100), commit-lint %s", n.Score, n.Version)
+	default:
+		return fmt.Sprintf("❌ invalid (%d/100, %d violation(s)), commit-lint %s", n.Score, len(n.Rules), n.Version)
+	}
+}
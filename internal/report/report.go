@@ -0,0 +1,187 @@
+package report
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"time"
+
+	"commit-linter/internal/linter"
+	"commit-linter/internal/stats"
+)
+
+//go:embed report.html.tmpl
+var pageTemplate string
+
+// Chart dimensions of the compliance-over-time SVG
+const (
+	chartWidth  = 720
+	chartHeight = 200
+)
+
+// Options describes the report being rendered
+type Options struct {
+	// Title is shown in the page header
+	Title string
+	// Range describes which commits were analyzed
+	Range string
+	// Bucket is the trend period (week or month)
+	Bucket string
+	// Preset is the commit convention whose rules are documented; the
+	// zero value means the default preset
+	Preset linter.Preset
+}
+
+// commitRow is one line of the commits table
+type commitRow struct {
+	Hash       string
+	ShortHash  string
+	Date       string
+	Author     string
+	Subject    string
+	Status     string
+	Score      int
+	Ignored    string
+	Violations []linter.Violation
+	Disabled   []string
+}
+
+// ruleDoc documents a rule and how often it fired
+type ruleDoc struct {
+	Name    string
+	Level   string
+	Message string
+	Count   int
+}
+
+// chartBar is one period of the compliance chart
+type chartBar struct {
+	Label      string
+	Compliance float64
+	Commits    int
+	X          float64
+	Y          float64
+	Width      float64
+	Height     float64
+}
+
+// pageData is passed to the template
+type pageData struct {
+	Options
+	Generated string
+	Summary   *stats.Report
+	Commits   []commitRow
+	Rules     []ruleDoc
+	Chart     []chartBar
+	Width     int
+	Height    int
+}
+
+// WriteHTML renders a self-contained HTML report: styles and scripts are
+// inlined and nothing is loaded from the network
+func WriteHTML(w io.Writer, entries []stats.Entry, opts Options) error {
+	tmpl, err := template.New("report").Parse(pageTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse report template: %v", err)
+	}
+
+	summary := stats.Summarize(entries, opts.Bucket)
+
+	data := pageData{
+		Options:   opts,
+		Generated: time.Now().Format("2006-01-02 15:04"),
+		Summary:   summary,
+		Commits:   commitRows(entries),
+		Rules:     ruleDocs(opts.Preset, summary),
+		Chart:     chartBars(summary.Trend),
+		Width:     chartWidth,
+		Height:    chartHeight,
+	}
+
+	return tmpl.Execute(w, data)
+}
+
+func commitRows(entries []stats.Entry) []commitRow {
+	rows := make([]commitRow, 0, len(entries))
+	for _, e := range entries {
+		row := commitRow{
+			Hash:      e.Commit.Hash,
+			ShortHash: e.Commit.ShortHash,
+			Date:      e.Commit.Date,
+			Author:    e.Commit.Author,
+			Subject:   e.Commit.Message,
+		}
+
+		switch {
+		case e.Result == nil:
+			row.Status = "ignored"
+			row.Ignored = e.Ignored
+		case e.Result.IsValid:
+			row.Status = "valid"
+		default:
+			row.Status = "invalid"
+		}
+
+		if e.Result != nil {
+			row.Score = e.Result.Score
+			row.Violations = e.Result.Violations
+			row.Disabled = e.Result.Disabled
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// ruleDocs lists every rule of preset with its violation count
+func ruleDocs(preset linter.Preset, summary *stats.Report) []ruleDoc {
+	if preset.Name == "" {
+		preset, _ = linter.LookupPreset(linter.DefaultPreset)
+	}
+
+	counts := map[string]int{}
+	for _, v := range summary.Violations {
+		counts[v.Rule] = v.Count
+	}
+
+	docs := []ruleDoc{{
+		Name:    "parse-failed",
+		Level:   "error",
+		Message: preset.ParseFailedMessage(),
+		Count:   counts["parse-failed"],
+	}}
+	for _, rule := range preset.Rules() {
+		docs = append(docs, ruleDoc{
+			Name:    rule.Name,
+			Level:   rule.Level,
+			Message: rule.Message,
+			Count:   counts[rule.Name],
+		})
+	}
+	return docs
+}
+
+// chartBars lays out one bar per trend period
+func chartBars(trend []*stats.Group) []chartBar {
+	if len(trend) == 0 {
+		return nil
+	}
+
+	slot := float64(chartWidth) / float64(len(trend))
+	plot := float64(chartHeight - 20) // leave room for labels
+
+	bars := make([]chartBar, 0, len(trend))
+	for i, g := range trend {
+		height := plot * g.Compliance / 100
+		bars = append(bars, chartBar{
+			Label:      g.Key,
+			Compliance: g.Compliance,
+			Commits:    g.Commits,
+			X:          float64(i)*slot + slot*0.15,
+			Width:      slot * 0.7,
+			Y:          plot - height,
+			Height:     height,
+		})
+	}
+	return bars
+}
@@ -0,0 +1,144 @@
+package semver
+
+import (
+	"strconv"
+	"strings"
+
+	"commit-linter/internal/git"
+	"commit-linter/internal/linter"
+)
+
+// Level is the kind of version increment
+type Level int
+
+// Bump levels, ordered by significance
+const (
+	None Level = iota
+	Patch
+	Minor
+	Major
+)
+
+func (l Level) String() string {
+	switch l {
+	case Patch:
+		return "patch"
+	case Minor:
+		return "minor"
+	case Major:
+		return "major"
+	}
+	return "none"
+}
+
+// MarshalText encodes the level as its name in JSON output
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// ZeroMode controls how breaking changes and features bump 0.x versions
+type ZeroMode string
+
+const (
+	// ZeroShift treats 0.x as initial development: breaking changes bump
+	// the minor version and features bump the patch version
+	ZeroShift ZeroMode = "shift"
+	// ZeroStrict applies the regular rules, so a breaking change on 0.x
+	// releases 1.0.0
+	ZeroStrict ZeroMode = "strict"
+)
+
+// Reason explains how a single commit contributed to the bump
+type Reason struct {
+	Hash    string `json:"hash"`
+	Subject string `json:"subject"`
+	Bump    Level  `json:"bump"`
+	Reason  string `json:"reason"`
+}
+
+// CommitLevel returns the bump a commit requires under preset, and why.
+// The preset must have a Release.
+func CommitLevel(c *git.Commit, preset linter.Preset) Reason {
+	parsed, ok := preset.Parse(c.FullMessage())
+	r := Reason{Hash: c.ShortHash, Subject: c.Message}
+
+	switch {
+	case !ok || parsed.Type == "":
+		r.Reason = "not a " + preset.Title + " commit"
+	case parsed.IsBreaking:
+		r.Bump = Major
+		r.Reason = "breaking change"
+	case preset.Release.IsFeature(parsed.Type):
+		r.Bump = Minor
+		r.Reason = "new feature"
+	case preset.Release.IsFix(parsed.Type):
+		r.Bump = Patch
+		r.Reason = parsed.Type + " commit"
+	default:
+		r.Reason = "type " + parsed.Type + " does not trigger a release"
+	}
+
+	return r
+}
+
+// Initial is the first release of a repository without release tags,
+// whatever the bump
+var Initial = Version{Minor: 1}
+
+// Apply increments v by the given level. The pre-release part is dropped.
+func Apply(v Version, level Level, mode ZeroMode) Version {
+	next := v.Core()
+
+	if next.Major == 0 && mode != ZeroStrict && level > Patch {
+		level--
+	}
+
+	switch level {
+	case Major:
+		next.Major++
+		next.Minor = 0
+		next.Patch = 0
+	case Minor:
+		next.Minor++
+		next.Patch = 0
+	case Patch:
+		next.Patch++
+	}
+
+	return next
+}
+
+// WithChannel appends a numbered pre-release identifier for the channel.
+// If latest is already a pre-release of the same version and channel its
+// counter is incremented (1.3.0-rc.1 -> 1.3.0-rc.2).
+func WithChannel(next, latest Version, channel string) Version {
+	n := 1
+	if latest.Core() == next.Core() && strings.HasPrefix(latest.PreRelease, channel+".") {
+		if prev, err := strconv.Atoi(strings.TrimPrefix(latest.PreRelease, channel+".")); err == nil {
+			n = prev + 1
+		}
+	}
+
+	next.PreRelease = channel + "." + strconv.Itoa(n)
+	return next
+}
+
+// Latest returns the highest version among tags carrying the prefix.
+// Pre-release tags are skipped unless includePre is set.
+func Latest(tags []string, prefix string, includePre bool) (string, Version, bool) {
+	var bestTag string
+	var best Version
+	found := false
+
+	for _, tag := range tags {
+		v, err := Parse(tag, prefix)
+		if err != nil || (!includePre && v.PreRelease != "") {
+			continue
+		}
+		if !found || v.Compare(best) > 0 {
+			bestTag, best, found = tag, v, true
+		}
+	}
+
+	return bestTag, best, found
+}
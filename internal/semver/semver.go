@@ -0,0 +1,114 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// versionRe matches MAJOR.MINOR.PATCH with an optional pre-release and
+// build metadata, as defined by semver.org
+var versionRe = regexp.MustCompile(`^(\d+)\.(\d+)\.(\d+)(?:-([0-9A-Za-z.-]+))?(?:\+[0-9A-Za-z.-]+)?$`)
+
+// Version is a semantic version
+type Version struct {
+	Major      int
+	Minor      int
+	Patch      int
+	PreRelease string
+}
+
+// Parse parses a version string, stripping the given tag prefix
+func Parse(s, prefix string) (Version, error) {
+	if !strings.HasPrefix(s, prefix) {
+		return Version{}, fmt.Errorf("%q does not start with %q", s, prefix)
+	}
+
+	m := versionRe.FindStringSubmatch(strings.TrimPrefix(s, prefix))
+	if m == nil {
+		return Version{}, fmt.Errorf("%q is not a semantic version", s)
+	}
+
+	major, _ := strconv.Atoi(m[1])
+	minor, _ := strconv.Atoi(m[2])
+	patch, _ := strconv.Atoi(m[3])
+
+	return Version{Major: major, Minor: minor, Patch: patch, PreRelease: m[4]}, nil
+}
+
+// String formats the version without a prefix
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.PreRelease != "" {
+		s += "-" + v.PreRelease
+	}
+	return s
+}
+
+// Core returns the version without its pre-release part
+func (v Version) Core() Version {
+	return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+}
+
+// Compare returns -1, 0 or 1 following semver precedence rules
+func (v Version) Compare(o Version) int {
+	for _, d := range []int{v.Major - o.Major, v.Minor - o.Minor, v.Patch - o.Patch} {
+		if d < 0 {
+			return -1
+		}
+		if d > 0 {
+			return 1
+		}
+	}
+
+	// A version without pre-release has higher precedence
+	switch {
+	case v.PreRelease == o.PreRelease:
+		return 0
+	case v.PreRelease == "":
+		return 1
+	case o.PreRelease == "":
+		return -1
+	}
+
+	return comparePreRelease(v.PreRelease, o.PreRelease)
+}
+
+// comparePreRelease compares dot-separated pre-release identifiers
+func comparePreRelease(a, b string) int {
+	as := strings.Split(a, ".")
+	bs := strings.Split(b, ".")
+
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+
+		switch {
+		case aErr == nil && bErr == nil:
+			if an != bn {
+				return sign(an - bn)
+			}
+		case aErr == nil:
+			return -1 // numeric identifiers sort before alphanumeric
+		case bErr == nil:
+			return 1
+		default:
+			if c := strings.Compare(as[i], bs[i]); c != 0 {
+				return c
+			}
+		}
+	}
+
+	return sign(len(as) - len(bs))
+}
+
+func sign(n int) int {
+	if n < 0 {
+		return -1
+	}
+	if n > 0 {
+		return 1
+	}
+	return 0
+}
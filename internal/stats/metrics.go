@@ -0,0 +1,134 @@
+package stats
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Metric exposition formats
+const (
+	FormatPrometheus  = "prometheus"
+	FormatOpenMetrics = "openmetrics"
+)
+
+// metricPrefix namespaces every exported metric
+const metricPrefix = "commitlint_"
+
+// metricWriter writes metric families in either the Prometheus text format
+// (what the node exporter textfile collector reads) or OpenMetrics, which
+// adds a closing # EOF.
+type metricWriter struct {
+	w           *bufio.Writer
+	openMetrics bool
+	labels      string
+}
+
+// WriteMetrics writes the report as gauges. Counts describe the analyzed
+// range, which shrinks or is rewritten between runs, so none of them is a
+// counter. labels (such as repo="api") are attached to every sample.
+func (r *Report) WriteMetrics(w io.Writer, format string, labels map[string]string) error {
+	mw := &metricWriter{
+		w:           bufio.NewWriter(w),
+		openMetrics: format == FormatOpenMetrics,
+		labels:      formatLabels(labels),
+	}
+
+	mw.gauge("commits", "Commits in the analyzed range, including ignored ones", float64(r.Commits))
+	mw.gauge("commits_linted", "Commits validated against the rules", float64(r.Linted))
+	mw.gauge("commits_ignored", "Commits skipped by ignore rules", float64(r.Ignored))
+	mw.gauge("commits_valid", "Linted commits without errors", float64(r.Valid))
+	mw.gauge("breaking_changes", "Commits marked as breaking changes", float64(r.BreakingChanges))
+	mw.gauge("compliance_ratio", "Share of linted commits that are valid (0-1)", r.Compliance/100)
+	mw.gauge("score_average", "Average commit score (0-100)", r.AvgScore)
+	mw.gauge("subject_length_average", "Average subject length in characters", r.AvgSubjectLength)
+
+	mw.header("violations", "gauge", "Rule violations in the analyzed range by rule and level")
+	for _, v := range r.Violations {
+		mw.sample("violations", map[string]string{"rule": v.Rule, "level": v.Level}, float64(v.Count))
+	}
+
+	mw.gauge("last_run_timestamp_seconds", "Unix time the metrics were generated", float64(time.Now().Unix()))
+
+	if mw.openMetrics {
+		mw.w.WriteString("# EOF\n")
+	}
+	return mw.w.Flush()
+}
+
+func (mw *metricWriter) gauge(name, help string, value float64) {
+	mw.header(name, "gauge", help)
+	mw.sample(name, nil, value)
+}
+
+// header writes the HELP and TYPE lines
+func (mw *metricWriter) header(name, kind, help string) {
+	family := metricPrefix + name
+	fmt.Fprintf(mw.w, "# HELP %s %s\n", family, help)
+	fmt.Fprintf(mw.w, "# TYPE %s %s\n", family, kind)
+}
+
+func (mw *metricWriter) sample(name string, labels map[string]string, value float64) {
+	all := mw.labels
+	if extra := formatLabels(labels); extra != "" {
+		if all != "" {
+			all += ","
+		}
+		all += extra
+	}
+	if all != "" {
+		all = "{" + all + "}"
+	}
+	fmt.Fprintf(mw.w, "%s%s%s %s\n", metricPrefix, name, all, strconv.FormatFloat(value, 'f', -1, 64))
+}
+
+var labelNameRe = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// reservedLabels are set by commit-lint itself; a sample with the same
+// label twice makes the whole exposition invalid
+var reservedLabels = map[string]string{
+	"rule":  "the violations metric sets it",
+	"level": "the violations metric sets it",
+	"repo":  "it is derived from the repository",
+}
+
+// CheckLabelName reports whether name can be used as a metric label. Names
+// starting with __ are reserved for Prometheus itself.
+func CheckLabelName(name string) error {
+	if !labelNameRe.MatchString(name) {
+		return fmt.Errorf("invalid label name %q: must match [a-zA-Z_][a-zA-Z0-9_]*", name)
+	}
+	if strings.HasPrefix(name, "__") {
+		return fmt.Errorf("invalid label name %q: names starting with __ are reserved", name)
+	}
+	if reason, ok := reservedLabels[name]; ok {
+		return fmt.Errorf("invalid label name %q: reserved, %s", name, reason)
+	}
+	return nil
+}
+
+// formatLabels renders labels as name="value" pairs in name order
+func formatLabels(labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, 0, len(names))
+	for _, name := range names {
+		pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", name, escapeLabel(labels[name])))
+	}
+	return strings.Join(pairs, ",")
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabel(value string) string {
+	return labelEscaper.Replace(value)
+}
@@ -0,0 +1,28 @@
+package stats
+
+import "testing"
+
+func TestCheckLabelName(t *testing.T) {
+	tests := []struct {
+		name  string
+		valid bool
+	}{
+		{"team", true},
+		{"_env", true},
+		{"cluster_2", true},
+		{"2cluster", false},
+		{"team-name", false},
+		{"", false},
+		{"__name__", false},
+		{"rule", false},
+		{"level", false},
+		{"repo", false},
+	}
+
+	for _, tt := range tests {
+		err := CheckLabelName(tt.name)
+		if (err == nil) != tt.valid {
+			t.Errorf("CheckLabelName(%q) = %v, want valid %t", tt.name, err, tt.valid)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package stats
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// RepoScan is the input for one repository of a multi-repository scan
+type RepoScan struct {
+	Name    string
+	Path    string
+	Bare    bool
+	Entries []Entry
+	// Err is set if the repository could not be scanned
+	Err error
+}
+
+// RepoResult summarizes one scanned repository
+type RepoResult struct {
+	// Rank orders repositories by compliance, 1 being the best; 0 for
+	// repositories without linted commits
+	Rank       int     `json:"rank"`
+	Name       string  `json:"name"`
+	Path       string  `json:"path"`
+	Bare       bool    `json:"bare"`
+	Commits    int     `json:"commits"`
+	Linted     int     `json:"linted"`
+	Ignored    int     `json:"ignored"`
+	Valid      int     `json:"valid"`
+	Compliance float64 `json:"compliance"`
+	AvgScore   float64 `json:"avgScore"`
+	// TopRule is the most violated rule
+	TopRule string `json:"topRule,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// ScanReport aggregates a multi-repository scan
+type ScanReport struct {
+	Repositories []*RepoResult `json:"repositories"`
+	Failed       int           `json:"failed"`
+	// Summary covers the commits of every repository together
+	Summary *Report `json:"summary"`
+}
+
+// Scan summarizes each repository, ranks them by compliance and aggregates
+// all their commits
+func Scan(repos []RepoScan, bucket string) *ScanReport {
+	report := &ScanReport{}
+	var all []Entry
+
+	for _, repo := range repos {
+		result := &RepoResult{Name: repo.Name, Path: repo.Path, Bare: repo.Bare}
+		report.Repositories = append(report.Repositories, result)
+
+		if repo.Err != nil {
+			result.Error = repo.Err.Error()
+			report.Failed++
+			continue
+		}
+
+		summary := Summarize(repo.Entries, bucket)
+		result.Commits = summary.Commits
+		result.Linted = summary.Linted
+		result.Ignored = summary.Ignored
+		result.Valid = summary.Valid
+		result.Compliance = summary.Compliance
+		result.AvgScore = summary.AvgScore
+		if len(summary.Violations) > 0 {
+			result.TopRule = summary.Violations[0].Rule
+		}
+
+		all = append(all, repo.Entries...)
+	}
+
+	sort.SliceStable(report.Repositories, func(i, j int) bool {
+		a, b := report.Repositories[i], report.Repositories[j]
+		if (a.Linted > 0) != (b.Linted > 0) {
+			return a.Linted > 0
+		}
+		if a.Compliance != b.Compliance {
+			return a.Compliance > b.Compliance
+		}
+		if a.AvgScore != b.AvgScore {
+			return a.AvgScore > b.AvgScore
+		}
+		return a.Name < b.Name
+	})
+	for i, r := range report.Repositories {
+		if r.Linted > 0 {
+			r.Rank = i + 1
+		}
+	}
+
+	report.Summary = Summarize(all, bucket)
+	return report
+}
+
+// WriteCSV writes one row per repository in rank order
+func (r *ScanReport) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"rank", "repository", "path", "bare", "commits", "linted", "ignored", "valid", "compliance", "avg_score", "top_rule", "error"})
+
+	f := func(v float64) string { return strconv.FormatFloat(v, 'f', 1, 64) }
+	n := strconv.Itoa
+
+	for _, repo := range r.Repositories {
+		rank := ""
+		if repo.Rank > 0 {
+			rank = n(repo.Rank)
+		}
+		cw.Write([]string{
+			rank, repo.Name, repo.Path, strconv.FormatBool(repo.Bare),
+			n(repo.Commits), n(repo.Linted), n(repo.Ignored), n(repo.Valid),
+			f(repo.Compliance), f(repo.AvgScore), repo.TopRule, repo.Error,
+		})
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
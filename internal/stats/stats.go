@@ -0,0 +1,234 @@
+package stats
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"commit-linter/internal/git"
+	"commit-linter/internal/linter"
+)
+
+// Bucket sizes for the score trend
+const (
+	BucketWeek  = "week"
+	BucketMonth = "month"
+)
+
+// Group aggregates the commits sharing a key (type, scope, author, period)
+type Group struct {
+	Key        string  `json:"key"`
+	Commits    int     `json:"commits"`
+	Valid      int     `json:"valid"`
+	Compliance float64 `json:"compliance"`
+	AvgScore   float64 `json:"avgScore"`
+
+	totalScore int
+}
+
+func (g *Group) add(result *linter.ValidationResult) {
+	g.Commits++
+	g.totalScore += result.Score
+	if result.IsValid {
+		g.Valid++
+	}
+	g.Compliance = float64(g.Valid) / float64(g.Commits) * 100
+	g.AvgScore = float64(g.totalScore) / float64(g.Commits)
+}
+
+// RuleCount is how often a rule was violated
+type RuleCount struct {
+	Rule  string `json:"rule"`
+	Level string `json:"level"`
+	Count int    `json:"count"`
+}
+
+// Report is the commit-quality summary of a set of commits
+type Report struct {
+	Commits          int         `json:"commits"`
+	Linted           int         `json:"linted"`
+	Ignored          int         `json:"ignored"`
+	Valid            int         `json:"valid"`
+	Compliance       float64     `json:"compliance"`
+	AvgScore         float64     `json:"avgScore"`
+	BreakingChanges  int         `json:"breakingChanges"`
+	AvgSubjectLength float64     `json:"avgSubjectLength"`
+	Types            []*Group    `json:"types"`
+	Scopes           []*Group    `json:"scopes"`
+	Authors          []*Group    `json:"authors"`
+	Trend            []*Group    `json:"trend"`
+	Violations       []RuleCount `json:"violations"`
+}
+
+// Entry is the outcome of checking one commit
+type Entry struct {
+	Commit *git.Commit
+	// Result is nil for ignored commits
+	Result *linter.ValidationResult
+	// Ignored is the reason the commit was skipped
+	Ignored string
+	// Parsed is the message as parsed by the linter's preset; nil means
+	// Conventional Commits
+	Parsed *linter.CommitMessage
+}
+
+// Summarize aggregates already validated commits
+func Summarize(entries []Entry, bucket string) *Report {
+	report := &Report{Commits: len(entries)}
+
+	types := map[string]*Group{}
+	scopes := map[string]*Group{}
+	authors := map[string]*Group{}
+	trend := map[string]*Group{}
+	rules := map[string]*RuleCount{}
+
+	totalScore := 0
+	totalSubject := 0
+
+	for _, e := range entries {
+		if e.Result == nil {
+			report.Ignored++
+			continue
+		}
+
+		c, result := e.Commit, e.Result
+		parsed := e.Parsed
+		if parsed == nil {
+			parsed = linter.ParseCommitMessage(c.FullMessage())
+		}
+
+		report.Linted++
+		totalScore += result.Score
+		totalSubject += len(c.Message)
+		if result.IsValid {
+			report.Valid++
+		}
+		if parsed.IsBreaking {
+			report.BreakingChanges++
+		}
+
+		commitType := parsed.Type
+		if commitType == "" {
+			commitType = "(none)"
+		}
+		group(types, commitType).add(result)
+		if parsed.Scope != "" {
+			group(scopes, parsed.Scope).add(result)
+		}
+		group(authors, c.Author).add(result)
+		group(trend, period(c.Date, bucket)).add(result)
+
+		for _, v := range result.Violations {
+			if rules[v.Rule] == nil {
+				rules[v.Rule] = &RuleCount{Rule: v.Rule, Level: v.Level}
+			}
+			rules[v.Rule].Count++
+		}
+	}
+
+	if report.Linted > 0 {
+		report.Compliance = float64(report.Valid) / float64(report.Linted) * 100
+		report.AvgScore = float64(totalScore) / float64(report.Linted)
+		report.AvgSubjectLength = float64(totalSubject) / float64(report.Linted)
+	}
+
+	report.Types = byCount(types)
+	report.Scopes = byCount(scopes)
+	report.Authors = byCount(authors)
+	report.Trend = byKey(trend)
+
+	report.Violations = []RuleCount{}
+	for _, rc := range rules {
+		report.Violations = append(report.Violations, *rc)
+	}
+	sort.Slice(report.Violations, func(i, j int) bool {
+		a, b := report.Violations[i], report.Violations[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Rule < b.Rule
+	})
+
+	return report
+}
+
+func group(groups map[string]*Group, key string) *Group {
+	if groups[key] == nil {
+		groups[key] = &Group{Key: key}
+	}
+	return groups[key]
+}
+
+// period maps a YYYY-MM-DD date to its week (2026-W42) or month (2026-10)
+func period(date, bucket string) string {
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return date
+	}
+	if bucket == BucketWeek {
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	}
+	return t.Format("2006-01")
+}
+
+// byCount orders groups by commit count, largest first
+func byCount(groups map[string]*Group) []*Group {
+	list := byKey(groups)
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].Commits > list[j].Commits
+	})
+	return list
+}
+
+// byKey orders groups by key, which is chronological for trend periods
+func byKey(groups map[string]*Group) []*Group {
+	list := []*Group{}
+	for _, g := range groups {
+		list = append(list, g)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Key < list[j].Key
+	})
+	return list
+}
+
+// WriteCSV writes the report in long format: one row per figure, with the
+// section and key identifying it
+func (r *Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"section", "key", "commits", "valid", "compliance", "avg_score", "count"})
+
+	f := func(v float64) string { return strconv.FormatFloat(v, 'f', 1, 64) }
+	n := strconv.Itoa
+
+	cw.Write([]string{"summary", "all", n(r.Linted), n(r.Valid), f(r.Compliance), f(r.AvgScore), ""})
+	cw.Write([]string{"summary", "ignored", "", "", "", "", n(r.Ignored)})
+	cw.Write([]string{"summary", "breaking_changes", "", "", "", "", n(r.BreakingChanges)})
+	cw.Write([]string{"summary", "avg_subject_length", "", "", "", "", f(r.AvgSubjectLength)})
+
+	sections := []struct {
+		name   string
+		groups []*Group
+	}{
+		{"type", r.Types},
+		{"scope", r.Scopes},
+		{"author", r.Authors},
+		{"trend", r.Trend},
+	}
+	for _, s := range sections {
+		for _, g := range s.groups {
+			cw.Write([]string{s.name, g.Key, n(g.Commits), n(g.Valid), f(g.Compliance), f(g.AvgScore), ""})
+		}
+	}
+
+	for _, v := range r.Violations {
+		cw.Write([]string{"violation", v.Rule, "", "", "", "", n(v.Count)})
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
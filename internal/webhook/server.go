@@ -0,0 +1,200 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"commit-linter/internal/ignore"
+	"commit-linter/internal/linter"
+)
+
+// maxPayload bounds the request body; push payloads carry at most a few
+// dozen commits
+const maxPayload = 5 << 20
+
+// Server lints the commit messages carried by webhook deliveries. It never
+// clones: push payloads include the messages, and pull requests are linted
+// by their title and description.
+type Server struct {
+	// Secret verifies deliveries and signs callbacks. Empty disables
+	// verification.
+	Secret string
+	// Callback, if set, receives every result as a JSON POST
+	Callback string
+	Linter   *linter.Linter
+	Matcher  *ignore.Matcher
+	Logger   *log.Logger
+	Client   *http.Client
+}
+
+// Result is returned to the sender and posted to the callback URL
+type Result struct {
+	Provider    string             `json:"provider"`
+	Event       string             `json:"event"`
+	Repository  string             `json:"repository"`
+	Ref         string             `json:"ref,omitempty"`
+	Valid       bool               `json:"valid"`
+	Commits     []CommitResult     `json:"commits,omitempty"`
+	PullRequest *PullRequestResult `json:"pullRequest,omitempty"`
+}
+
+// CommitResult is the outcome for one pushed commit
+type CommitResult struct {
+	ID      string `json:"id"`
+	Subject string `json:"subject"`
+	// Ignored is the reason the commit was skipped by ignore rules
+	Ignored string                   `json:"ignored,omitempty"`
+	Result  *linter.ValidationResult `json:"result,omitempty"`
+}
+
+// PullRequestResult is the outcome for a pull request title and description
+type PullRequestResult struct {
+	PullRequest
+	Result *linter.ValidationResult `json:"result"`
+}
+
+// Handler returns the HTTP routes: POST /webhook and GET /healthz
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/webhook", s.handleWebhook)
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "ok\n")
+	})
+	return mux
+}
+
+func (s *Server) handleWebhook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPayload))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	provider, eventName, ok := DetectProvider(r.Header)
+	if !ok {
+		http.Error(w, "unknown webhook sender", http.StatusBadRequest)
+		return
+	}
+
+	if s.Secret != "" {
+		if err := Verify(provider, r.Header, body, s.Secret); err != nil {
+			s.Logger.Printf("rejected %s delivery: %v", provider, err)
+			http.Error(w, "verification failed", http.StatusUnauthorized)
+			return
+		}
+	}
+
+	event, err := Parse(provider, eventName, body)
+	if errors.Is(err, ErrSkipped) {
+		writeJSON(w, http.StatusAccepted, map[string]string{"skipped": err.Error()})
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result := s.Lint(event)
+	s.Logger.Printf("%s %s %s: valid=%t", provider, event.Kind, event.Repository, result.Valid)
+
+	if s.Callback != "" {
+		go s.postCallback(result)
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// Lint validates the commits or pull request of an event
+func (s *Server) Lint(event *Event) *Result {
+	result := &Result{
+		Provider:   event.Provider,
+		Event:      event.Kind,
+		Repository: event.Repository,
+		Ref:        event.Ref,
+		Valid:      true,
+	}
+
+	for _, commit := range event.Commits {
+		cr := CommitResult{ID: commit.Hash, Subject: commit.Message}
+		if reason, ok := s.Matcher.Match(commit); ok {
+			cr.Ignored = reason
+		} else {
+			cr.Result = s.Linter.Validate(commit.FullMessage())
+			result.Valid = result.Valid && cr.Result.IsValid
+		}
+		result.Commits = append(result.Commits, cr)
+	}
+
+	if pr := event.PullRequest; pr != nil {
+		// A squash merge produces "title\n\nbody", so lint exactly that
+		message := strings.TrimSpace(pr.Title)
+		if body := strings.TrimSpace(strings.ReplaceAll(pr.Body, "\r\n", "\n")); body != "" {
+			message += "\n\n" + body
+		}
+
+		prResult := s.Linter.Validate(message)
+		prResult.Suppress(linter.PullRequestExemptRules())
+		result.PullRequest = &PullRequestResult{PullRequest: *pr, Result: prResult}
+		result.Valid = result.Valid && prResult.IsValid
+	}
+
+	return result
+}
+
+// postCallback delivers a result to the callback URL. When a secret is
+// set the body is signed like GitHub deliveries, in
+// X-Commit-Lint-Signature-256.
+func (s *Server) postCallback(result *Result) {
+	data, err := json.Marshal(result)
+	if err != nil {
+		s.Logger.Printf("callback: %v", err)
+		return
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.Callback, bytes.NewReader(data))
+	if err != nil {
+		s.Logger.Printf("callback: %v", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "commit-lint")
+	if s.Secret != "" {
+		req.Header.Set("X-Commit-Lint-Signature-256", "sha256="+Sign(data, s.Secret))
+	}
+
+	client := s.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		s.Logger.Printf("callback: %v", err)
+		return
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 300 {
+		s.Logger.Printf("callback: %s returned %s", s.Callback, resp.Status)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
@@ -0,0 +1,215 @@
+package webhook
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"commit-linter/internal/config"
+	"commit-linter/internal/git"
+	"commit-linter/internal/ignore"
+	"commit-linter/internal/linter"
+)
+
+const testSecret = "It's a Secret to Everybody"
+
+// deliveries are the recorded payloads in examples/webhooks
+var deliveries = []struct {
+	file     string
+	provider string
+	event    string
+	kind     string
+	valid    bool
+	commits  int
+}{
+	{"github-push.json", ProviderGitHub, "push", KindPush, false, 2},
+	{"github-pull_request.json", ProviderGitHub, "pull_request", KindPullRequest, true, 0},
+	{"gitlab-push.json", ProviderGitLab, "Push Hook", KindPush, true, 1},
+	{"gitlab-merge_request.json", ProviderGitLab, "Merge Request Hook", KindPullRequest, false, 0},
+	{"gitea-push.json", ProviderGitea, "push", KindPush, true, 1},
+}
+
+func readPayload(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("..", "..", "examples", "webhooks", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func newTestServer(t *testing.T, callback string) *Server {
+	t.Helper()
+	matcher, err := ignore.New(config.Default().Ignore)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Server{
+		Secret:   testSecret,
+		Callback: callback,
+		Linter:   linter.New(linter.DefaultOptions()),
+		Matcher:  matcher,
+		Logger:   log.New(io.Discard, "", 0),
+	}
+}
+
+// newDelivery builds a request as the provider would send it, signed with
+// secret
+func newDelivery(provider, event string, body []byte, secret string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(string(body)))
+	req.Header.Set("Content-Type", "application/json")
+	switch provider {
+	case ProviderGitHub:
+		req.Header.Set("X-GitHub-Event", event)
+		req.Header.Set("X-Hub-Signature-256", "sha256="+Sign(body, secret))
+	case ProviderGitea:
+		req.Header.Set("X-Gitea-Event", event)
+		req.Header.Set("X-GitHub-Event", event)
+		req.Header.Set("X-Gitea-Signature", Sign(body, secret))
+	case ProviderGitLab:
+		req.Header.Set("X-Gitlab-Event", event)
+		req.Header.Set("X-Gitlab-Token", secret)
+	}
+	return req
+}
+
+func TestHandlerRecordedPayloads(t *testing.T) {
+	handler := newTestServer(t, "").Handler()
+
+	for _, d := range deliveries {
+		t.Run(d.file, func(t *testing.T) {
+			body := readPayload(t, d.file)
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, newDelivery(d.provider, d.event, body, testSecret))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body)
+			}
+
+			var result Result
+			if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+				t.Fatal(err)
+			}
+			if result.Provider != d.provider || result.Event != d.kind {
+				t.Errorf("got %s %s, want %s %s", result.Provider, result.Event, d.provider, d.kind)
+			}
+			if result.Valid != d.valid {
+				t.Errorf("valid = %t, want %t", result.Valid, d.valid)
+			}
+			if len(result.Commits) != d.commits {
+				t.Errorf("%d commits, want %d", len(result.Commits), d.commits)
+			}
+			if (d.kind == KindPullRequest) != (result.PullRequest != nil) {
+				t.Errorf("pull request result = %v", result.PullRequest)
+			}
+		})
+	}
+}
+
+func TestHandlerRejectsWrongSecret(t *testing.T) {
+	handler := newTestServer(t, "").Handler()
+
+	for _, d := range deliveries {
+		t.Run(d.file, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, newDelivery(d.provider, d.event, readPayload(t, d.file), "wrong"))
+			if rec.Code != http.StatusUnauthorized {
+				t.Errorf("status = %d, want 401", rec.Code)
+			}
+		})
+	}
+}
+
+func TestHandlerRejectsTamperedBody(t *testing.T) {
+	handler := newTestServer(t, "").Handler()
+	body := readPayload(t, "github-push.json")
+
+	req := newDelivery(ProviderGitHub, "push", body, testSecret)
+	tampered := strings.Replace(string(body), "Fixed stuff.", "fix: stuff", 1)
+	req.Body = io.NopCloser(strings.NewReader(tampered))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want 401", rec.Code)
+	}
+}
+
+func TestHandlerSkipsUnrelatedEvents(t *testing.T) {
+	handler := newTestServer(t, "").Handler()
+	body := []byte(`{"zen": "Keep it logically awesome."}`)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newDelivery(ProviderGitHub, "ping", body, testSecret))
+	if rec.Code != http.StatusAccepted {
+		t.Errorf("status = %d, want 202", rec.Code)
+	}
+}
+
+func TestCallback(t *testing.T) {
+	type delivery struct {
+		body      []byte
+		signature string
+	}
+	received := make(chan delivery, 1)
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- delivery{body, r.Header.Get("X-Commit-Lint-Signature-256")}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer stub.Close()
+
+	handler := newTestServer(t, stub.URL).Handler()
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newDelivery(ProviderGitHub, "push", readPayload(t, "github-push.json"), testSecret))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+
+	select {
+	case got := <-received:
+		if want := "sha256=" + Sign(got.body, testSecret); got.signature != want {
+			t.Errorf("signature = %q, want %q", got.signature, want)
+		}
+
+		var result Result
+		if err := json.Unmarshal(got.body, &result); err != nil {
+			t.Fatal(err)
+		}
+		if result.Repository != "octo-org/payments" || result.Valid || len(result.Commits) != 2 {
+			t.Fatalf("callback result = %+v", result)
+		}
+		if c := result.Commits[1]; c.Result == nil || c.Result.IsValid {
+			t.Errorf("second commit = %+v, want invalid", c)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("callback was not delivered")
+	}
+}
+
+func TestLintCombinesCommitsAndPullRequest(t *testing.T) {
+	server := newTestServer(t, "")
+	event := &Event{
+		Provider: ProviderGitHub,
+		Kind:     KindPullRequest,
+		Commits: []*git.Commit{
+			newCommit("0d1a26e67d8f5eaf1f6ba5c57fc3c7d91ac0fd1c", "Fixed stuff.", "Jo Doe", "jo@example.com"),
+		},
+		PullRequest: &PullRequest{Number: 1, Title: "feat(api): add refund endpoint"},
+	}
+
+	result := server.Lint(event)
+	if result.PullRequest == nil || !result.PullRequest.Result.IsValid {
+		t.Fatalf("pull request result = %+v, want valid", result.PullRequest)
+	}
+	if result.Valid {
+		t.Error("event is valid although a commit failed")
+	}
+}
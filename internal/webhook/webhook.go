@@ -0,0 +1,298 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"commit-linter/internal/git"
+)
+
+// Supported webhook providers
+const (
+	ProviderGitHub = "github"
+	ProviderGitLab = "gitlab"
+	ProviderGitea  = "gitea"
+)
+
+// Event kinds that are linted
+const (
+	KindPush        = "push"
+	KindPullRequest = "pull_request"
+)
+
+// ErrSkipped is returned for deliveries that carry nothing to lint, such as
+// pings, closed pull requests and unrelated events
+var ErrSkipped = errors.New("event skipped")
+
+// Event is the provider-independent content of a webhook delivery
+type Event struct {
+	Provider   string
+	Kind       string
+	Repository string
+	Ref        string
+	// Commits are the new commits of a push, oldest first
+	Commits []*git.Commit
+	// PullRequest is set for pull and merge request events
+	PullRequest *PullRequest
+}
+
+// PullRequest is a pull or merge request whose title and description a
+// squash merge turns into a commit message
+type PullRequest struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	Body    string `json:"body"`
+	URL     string `json:"url"`
+	HeadSHA string `json:"headSha"`
+}
+
+// DetectProvider identifies the sender from its event header. Gitea also
+// sends X-GitHub-Event for compatibility, so it is checked first.
+func DetectProvider(h http.Header) (provider, event string, ok bool) {
+	switch {
+	case h.Get("X-Gitea-Event") != "":
+		return ProviderGitea, h.Get("X-Gitea-Event"), true
+	case h.Get("X-Gitlab-Event") != "":
+		return ProviderGitLab, h.Get("X-Gitlab-Event"), true
+	case h.Get("X-GitHub-Event") != "":
+		return ProviderGitHub, h.Get("X-GitHub-Event"), true
+	}
+	return "", "", false
+}
+
+// Verify checks that a delivery was sent with the shared secret. GitHub and
+// Gitea sign the body with HMAC-SHA256; GitLab sends the secret token
+// itself.
+func Verify(provider string, h http.Header, body []byte, secret string) error {
+	switch provider {
+	case ProviderGitHub:
+		signature, ok := strings.CutPrefix(h.Get("X-Hub-Signature-256"), "sha256=")
+		if !ok {
+			return fmt.Errorf("missing X-Hub-Signature-256 header")
+		}
+		return verifyHMAC(body, secret, signature)
+	case ProviderGitea:
+		signature := h.Get("X-Gitea-Signature")
+		if signature == "" {
+			return fmt.Errorf("missing X-Gitea-Signature header")
+		}
+		return verifyHMAC(body, secret, signature)
+	case ProviderGitLab:
+		token := h.Get("X-Gitlab-Token")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(secret)) != 1 {
+			return fmt.Errorf("invalid X-Gitlab-Token")
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported provider %q", provider)
+}
+
+// Sign returns the hex HMAC-SHA256 of body, as sent by GitHub (after
+// "sha256=") and Gitea
+func Sign(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func verifyHMAC(body []byte, secret, signature string) error {
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("malformed signature")
+	}
+	want, _ := hex.DecodeString(Sign(body, secret))
+	if !hmac.Equal(got, want) {
+		return fmt.Errorf("signature mismatch")
+	}
+	return nil
+}
+
+// Parse decodes a delivery. It returns ErrSkipped (wrapped with the
+// reason) for events that carry nothing to lint.
+func Parse(provider, event string, body []byte) (*Event, error) {
+	switch provider {
+	case ProviderGitHub, ProviderGitea:
+		switch event {
+		case "push":
+			return parsePush(provider, body)
+		case "pull_request":
+			return parseGitHubPullRequest(provider, body)
+		}
+	case ProviderGitLab:
+		switch event {
+		case "Push Hook":
+			return parsePush(provider, body)
+		case "Merge Request Hook":
+			return parseGitLabMergeRequest(body)
+		}
+	}
+	return nil, fmt.Errorf("%w: %s %q event", ErrSkipped, provider, event)
+}
+
+// pushPayload covers the push events of all three providers, which share
+// the commits layout
+type pushPayload struct {
+	Ref     string `json:"ref"`
+	Deleted bool   `json:"deleted"`
+	Commits []struct {
+		ID       string `json:"id"`
+		Message  string `json:"message"`
+		Distinct *bool  `json:"distinct"`
+		Author   struct {
+			Name  string `json:"name"`
+			Email string `json:"email"`
+		} `json:"author"`
+	} `json:"commits"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+	Project struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+}
+
+func parsePush(provider string, body []byte) (*Event, error) {
+	var p pushPayload
+	if err := json.Unmarshal(body, &p); err != nil {
+		return nil, fmt.Errorf("malformed push payload: %v", err)
+	}
+	if p.Deleted {
+		return nil, fmt.Errorf("%w: %s was deleted", ErrSkipped, p.Ref)
+	}
+
+	e := &Event{
+		Provider:   provider,
+		Kind:       KindPush,
+		Repository: p.Repository.FullName,
+		Ref:        p.Ref,
+	}
+	if provider == ProviderGitLab {
+		e.Repository = p.Project.PathWithNamespace
+	}
+
+	for _, c := range p.Commits {
+		// GitHub marks commits that were already pushed to another branch
+		if c.Distinct != nil && !*c.Distinct {
+			continue
+		}
+		e.Commits = append(e.Commits, newCommit(c.ID, c.Message, c.Author.Name, c.Author.Email))
+	}
+
+	return e, nil
+}
+
+// newCommit builds a commit from a payload, splitting the message the way
+// git log does
+func newCommit(id, message, author, email string) *git.Commit {
+	message = strings.ReplaceAll(message, "\r\n", "\n")
+	subject, body, _ := strings.Cut(strings.TrimSpace(message), "\n")
+
+	short := id
+	if len(short) > 7 {
+		short = short[:7]
+	}
+
+	return &git.Commit{
+		Hash:        id,
+		ShortHash:   short,
+		Author:      author,
+		AuthorEmail: email,
+		Message:     subject,
+		Body:        strings.TrimSpace(body),
+	}
+}
+
+// lintedActions are the pull request actions that change what a squash
+// merge would commit
+var lintedActions = map[string]bool{
+	// GitHub and Gitea
+	"opened": true, "edited": true, "reopened": true, "synchronize": true, "synchronized": true,
+	// GitLab
+	"open": true, "update": true, "reopen": true,
+}
+
+type gitHubPullRequestPayload struct {
+	Action      string `json:"action"`
+	Number      int    `json:"number"`
+	PullRequest struct {
+		Title   string `json:"title"`
+		Body    string `json:"body"`
+		HTMLURL string `json:"html_url"`
+		Head    struct {
+			SHA string `json:"sha"`
+		} `json:"head"`
+	} `json:"pull_request"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+func parseGitHubPullRequest(provider string, body []byte) (*Event, error) {
+	var p gitHubPullRequestPayload
+	if err := json.Unmarshal(body, &p); err != nil {
+		return nil, fmt.Errorf("malformed pull_request payload: %v", err)
+	}
+	if !lintedActions[p.Action] {
+		return nil, fmt.Errorf("%w: pull request %s", ErrSkipped, p.Action)
+	}
+
+	return &Event{
+		Provider:   provider,
+		Kind:       KindPullRequest,
+		Repository: p.Repository.FullName,
+		PullRequest: &PullRequest{
+			Number:  p.Number,
+			Title:   p.PullRequest.Title,
+			Body:    p.PullRequest.Body,
+			URL:     p.PullRequest.HTMLURL,
+			HeadSHA: p.PullRequest.Head.SHA,
+		},
+	}, nil
+}
+
+type gitLabMergeRequestPayload struct {
+	ObjectAttributes struct {
+		IID         int    `json:"iid"`
+		Title       string `json:"title"`
+		Description string `json:"description"`
+		URL         string `json:"url"`
+		Action      string `json:"action"`
+		LastCommit  struct {
+			ID string `json:"id"`
+		} `json:"last_commit"`
+	} `json:"object_attributes"`
+	Project struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+}
+
+func parseGitLabMergeRequest(body []byte) (*Event, error) {
+	var p gitLabMergeRequestPayload
+	if err := json.Unmarshal(body, &p); err != nil {
+		return nil, fmt.Errorf("malformed merge request payload: %v", err)
+	}
+	attrs := p.ObjectAttributes
+	if !lintedActions[attrs.Action] {
+		return nil, fmt.Errorf("%w: merge request %s", ErrSkipped, attrs.Action)
+	}
+
+	return &Event{
+		Provider:   ProviderGitLab,
+		Kind:       KindPullRequest,
+		Repository: p.Project.PathWithNamespace,
+		PullRequest: &PullRequest{
+			Number:  attrs.IID,
+			Title:   attrs.Title,
+			Body:    attrs.Description,
+			URL:     attrs.URL,
+			HeadSHA: attrs.LastCommit.ID,
+		},
+	}, nil
+}